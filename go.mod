@@ -7,5 +7,6 @@ require (
 	github.com/gofrs/uuid v3.2.0+incompatible
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/uptrace/bun v0.4.2
+	golang.org/x/net v0.0.0-20210405180319-a5a99cb37ef4
 	google.golang.org/protobuf v1.27.1
 )
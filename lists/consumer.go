@@ -0,0 +1,194 @@
+package lists
+
+import (
+	"context"
+
+	"github.com/janartodesk/domain-design/pkg/consumer"
+	"github.com/uptrace/bun"
+	"google.golang.org/protobuf/proto"
+)
+
+// Consumer dispatches subscriber list events to typed handlers.
+//
+// Handlers run in a transaction that records the event as handled, which is
+// available to them through consumer.TxFromContext along with the event's
+// envelope through consumer.EnvelopeFromContext.
+type Consumer struct {
+	*consumer.Consumer
+}
+
+// NewConsumer creates a consumer of subscriber list events. Feed it events by
+// passing it to NewRelay.
+func NewConsumer(db *bun.DB, name string) *Consumer {
+	return &Consumer{
+		Consumer: consumer.NewConsumer(db, name),
+	}
+}
+
+// OnListCreated registers the handler of ListCreated events.
+func (c *Consumer) OnListCreated(fn func(context.Context, *ListCreated) error) {
+	c.Handle(&ListCreated{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*ListCreated))
+	})
+}
+
+// OnListDeleted registers the handler of ListDeleted events.
+func (c *Consumer) OnListDeleted(fn func(context.Context, *ListDeleted) error) {
+	c.Handle(&ListDeleted{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*ListDeleted))
+	})
+}
+
+// OnListExported registers the handler of ListExported events.
+func (c *Consumer) OnListExported(fn func(context.Context, *ListExported) error) {
+	c.Handle(&ListExported{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*ListExported))
+	})
+}
+
+// OnListRenamed registers the handler of ListRenamed events.
+func (c *Consumer) OnListRenamed(fn func(context.Context, *ListRenamed) error) {
+	c.Handle(&ListRenamed{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*ListRenamed))
+	})
+}
+
+// OnListSchemaChanged registers the handler of ListSchemaChanged events.
+func (c *Consumer) OnListSchemaChanged(fn func(context.Context, *ListSchemaChanged) error) {
+	c.Handle(&ListSchemaChanged{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*ListSchemaChanged))
+	})
+}
+
+// OnOrganizationCreated registers the handler of OrganizationCreated events.
+func (c *Consumer) OnOrganizationCreated(fn func(context.Context, *OrganizationCreated) error) {
+	c.Handle(&OrganizationCreated{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*OrganizationCreated))
+	})
+}
+
+// OnOrganizationRenamed registers the handler of OrganizationRenamed events.
+func (c *Consumer) OnOrganizationRenamed(fn func(context.Context, *OrganizationRenamed) error) {
+	c.Handle(&OrganizationRenamed{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*OrganizationRenamed))
+	})
+}
+
+// OnSoftBounceRecorded registers the handler of SoftBounceRecorded events.
+func (c *Consumer) OnSoftBounceRecorded(fn func(context.Context, *SoftBounceRecorded) error) {
+	c.Handle(&SoftBounceRecorded{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SoftBounceRecorded))
+	})
+}
+
+// OnSoftBouncesReset registers the handler of SoftBouncesReset events.
+func (c *Consumer) OnSoftBouncesReset(fn func(context.Context, *SoftBouncesReset) error) {
+	c.Handle(&SoftBouncesReset{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SoftBouncesReset))
+	})
+}
+
+// OnSubscriberCreated registers the handler of SubscriberCreated events.
+func (c *Consumer) OnSubscriberCreated(fn func(context.Context, *SubscriberCreated) error) {
+	c.Handle(&SubscriberCreated{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SubscriberCreated))
+	})
+}
+
+// OnSubscriberForgotten registers the handler of SubscriberForgotten events.
+func (c *Consumer) OnSubscriberForgotten(fn func(context.Context, *SubscriberForgotten) error) {
+	c.Handle(&SubscriberForgotten{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SubscriberForgotten))
+	})
+}
+
+// OnSubscriberOptedIn registers the handler of SubscriberOptedIn events.
+func (c *Consumer) OnSubscriberOptedIn(fn func(context.Context, *SubscriberOptedIn) error) {
+	c.Handle(&SubscriberOptedIn{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SubscriberOptedIn))
+	})
+}
+
+// OnSubscriberOptedOut registers the handler of SubscriberOptedOut events.
+func (c *Consumer) OnSubscriberOptedOut(fn func(context.Context, *SubscriberOptedOut) error) {
+	c.Handle(&SubscriberOptedOut{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SubscriberOptedOut))
+	})
+}
+
+// OnSubscriberResubscribed registers the handler of SubscriberResubscribed events.
+func (c *Consumer) OnSubscriberResubscribed(fn func(context.Context, *SubscriberResubscribed) error) {
+	c.Handle(&SubscriberResubscribed{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SubscriberResubscribed))
+	})
+}
+
+// OnSubscriberSubscribed registers the handler of SubscriberSubscribed events.
+func (c *Consumer) OnSubscriberSubscribed(fn func(context.Context, *SubscriberSubscribed) error) {
+	c.Handle(&SubscriberSubscribed{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SubscriberSubscribed))
+	})
+}
+
+// OnSubscriberUnsubscribed registers the handler of SubscriberUnsubscribed events.
+func (c *Consumer) OnSubscriberUnsubscribed(fn func(context.Context, *SubscriberUnsubscribed) error) {
+	c.Handle(&SubscriberUnsubscribed{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SubscriberUnsubscribed))
+	})
+}
+
+// OnSubscriptionBounced registers the handler of SubscriptionBounced events.
+func (c *Consumer) OnSubscriptionBounced(fn func(context.Context, *SubscriptionBounced) error) {
+	c.Handle(&SubscriptionBounced{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SubscriptionBounced))
+	})
+}
+
+// OnSubscriptionComplained registers the handler of SubscriptionComplained events.
+func (c *Consumer) OnSubscriptionComplained(fn func(context.Context, *SubscriptionComplained) error) {
+	c.Handle(&SubscriptionComplained{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SubscriptionComplained))
+	})
+}
+
+// OnSubscriptionDataChanged registers the handler of SubscriptionDataChanged events.
+func (c *Consumer) OnSubscriptionDataChanged(fn func(context.Context, *SubscriptionDataChanged) error) {
+	c.Handle(&SubscriptionDataChanged{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SubscriptionDataChanged))
+	})
+}
+
+// OnSubscriptionExpired registers the handler of SubscriptionExpired events.
+func (c *Consumer) OnSubscriptionExpired(fn func(context.Context, *SubscriptionExpired) error) {
+	c.Handle(&SubscriptionExpired{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SubscriptionExpired))
+	})
+}
+
+// OnSubscriptionForgotten registers the handler of SubscriptionForgotten events.
+func (c *Consumer) OnSubscriptionForgotten(fn func(context.Context, *SubscriptionForgotten) error) {
+	c.Handle(&SubscriptionForgotten{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SubscriptionForgotten))
+	})
+}
+
+// OnSubscriptionSuppressed registers the handler of SubscriptionSuppressed events.
+func (c *Consumer) OnSubscriptionSuppressed(fn func(context.Context, *SubscriptionSuppressed) error) {
+	c.Handle(&SubscriptionSuppressed{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SubscriptionSuppressed))
+	})
+}
+
+// OnSuppressionAdded registers the handler of SuppressionAdded events.
+func (c *Consumer) OnSuppressionAdded(fn func(context.Context, *SuppressionAdded) error) {
+	c.Handle(&SuppressionAdded{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SuppressionAdded))
+	})
+}
+
+// OnSuppressionRemoved registers the handler of SuppressionRemoved events.
+func (c *Consumer) OnSuppressionRemoved(fn func(context.Context, *SuppressionRemoved) error) {
+	c.Handle(&SuppressionRemoved{}, func(ctx context.Context, event proto.Message) error {
+		return fn(ctx, event.(*SuppressionRemoved))
+	})
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+)
+
+// Confirmation is a single-use token confirming a pending subscription.
+//
+// The token is only known when the confirmation is created. Confirmations read
+// back from storage carry the token only when they were looked up by it.
+type Confirmation struct {
+	PK             uuid.UUID
+	Token          string
+	OrganizationPK uuid.UUID
+	ListPK         uuid.UUID
+	SubscriptionPK uuid.UUID
+	ExpiresAt      time.Time
+
+	// IsResubscription is set when the confirmation reactivates a previously
+	// cancelled subscription.
+	IsResubscription bool
+
+	// PreviousState is the state the subscription was in before the opt-in,
+	// which it returns to when the confirmation expires. It is empty when the
+	// opt-in created the subscription.
+	PreviousState SubscriptionState
+}
+
+// Validate the confirmation.
+func (c *Confirmation) Validate() error {
+	return validationError(validation.ValidateStruct(c,
+		validation.Field(&c.PK, validation.Required),
+		validation.Field(&c.Token, validation.Required),
+		validation.Field(&c.OrganizationPK, validation.Required),
+		validation.Field(&c.ListPK, validation.Required),
+		validation.Field(&c.SubscriptionPK, validation.Required),
+		validation.Field(&c.ExpiresAt, validation.Required),
+	))
+}
+
+// IsExpired reports whether the confirmation has expired at the given time.
+func (c *Confirmation) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// CreateConfirmation creates a confirmation for a pending subscription that was
+// in the previous state before the opt-in.
+func CreateConfirmation(subscription Subscription, isResubscription bool, previousState SubscriptionState, ttl time.Duration) (*Confirmation, error) {
+	token := make([]byte, 32)
+
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	c := &Confirmation{
+		PK:             uuid.Must(uuid.NewV4()),
+		Token:          base64.RawURLEncoding.EncodeToString(token),
+		OrganizationPK: subscription.OrganizationPK,
+		ListPK:         subscription.ListPK,
+		SubscriptionPK: subscription.PK,
+		ExpiresAt:      time.Now().Add(ttl),
+
+		IsResubscription: isResubscription,
+		PreviousState:    previousState,
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
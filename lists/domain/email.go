@@ -0,0 +1,326 @@
+package domain
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// Email address length limits of RFC 5321.
+const (
+	maxLocalPartLength    = 64
+	maxDomainLength       = 255
+	maxEmailAddressLength = 254
+	maxDomainLabelLength  = 63
+)
+
+// ErrInvalidEmailAddress is returned for email addresses that are not valid
+// RFC 5321 mailbox addresses.
+var ErrInvalidEmailAddress error = &ValidationError{Message: "invalid email address"}
+
+// EmailAddress is a normalized email address.
+//
+// The local part is kept as given, while the domain is mapped and converted to
+// punycode following UTS #46, so that every spelling of an internationalized
+// domain name has the same form.
+type EmailAddress string
+
+// NewEmailAddress returns a normalized email address. An address that fails to
+// parse is returned trimmed and fails validation.
+func NewEmailAddress(addr string) EmailAddress {
+	if a, err := ParseEmailAddress(addr); err == nil {
+		return a
+	}
+
+	return EmailAddress(strings.TrimSpace(addr))
+}
+
+// ParseEmailAddress parses and normalizes an email address.
+//
+// Only bare addr-spec addresses are accepted, with a dot-atom or quoted-string
+// local part and a host name or address literal domain, as permitted by
+// RFC 5321 and RFC 5322. UTF-8 local parts of RFC 6531 are accepted.
+func ParseEmailAddress(addr string) (EmailAddress, error) {
+	addr = strings.TrimSpace(addr)
+
+	at := strings.LastIndexByte(addr, '@')
+	if at < 0 {
+		return "", fmt.Errorf("%w: missing @", ErrInvalidEmailAddress)
+	}
+
+	local, domain := addr[:at], addr[at+1:]
+
+	if err := validateLocalPart(local); err != nil {
+		return "", err
+	}
+
+	domain, err := normalizeDomain(domain)
+	if err != nil {
+		return "", err
+	}
+
+	res := local + "@" + domain
+
+	if len(res) > maxEmailAddressLength {
+		return "", fmt.Errorf("%w: address too long", ErrInvalidEmailAddress)
+	}
+
+	return EmailAddress(res), nil
+}
+
+// Validate the email address.
+func (a EmailAddress) Validate() error {
+	_, err := ParseEmailAddress(string(a))
+
+	return err
+}
+
+// LocalPart returns the part of the address before the domain.
+func (a EmailAddress) LocalPart() string {
+	if at := strings.LastIndexByte(string(a), '@'); at >= 0 {
+		return string(a[:at])
+	}
+
+	return string(a)
+}
+
+// Domain returns the domain of the address.
+func (a EmailAddress) Domain() string {
+	if at := strings.LastIndexByte(string(a), '@'); at >= 0 {
+		return string(a[at+1:])
+	}
+
+	return ""
+}
+
+// providerRule describes how a mailbox provider maps addresses to mailboxes.
+type providerRule struct {
+	domain       string
+	ignoreDots   bool
+	tagSeparator string
+}
+
+var providerRules = map[string]providerRule{
+	"gmail.com":      {domain: "gmail.com", ignoreDots: true, tagSeparator: "+"},
+	"googlemail.com": {domain: "gmail.com", ignoreDots: true, tagSeparator: "+"},
+	"outlook.com":    {domain: "outlook.com", tagSeparator: "+"},
+	"hotmail.com":    {domain: "hotmail.com", tagSeparator: "+"},
+	"live.com":       {domain: "live.com", tagSeparator: "+"},
+	"icloud.com":     {domain: "icloud.com", tagSeparator: "+"},
+	"me.com":         {domain: "me.com", tagSeparator: "+"},
+	"fastmail.com":   {domain: "fastmail.com", tagSeparator: "+"},
+	"protonmail.com": {domain: "protonmail.com", tagSeparator: "+"},
+	"proton.me":      {domain: "proton.me", tagSeparator: "+"},
+}
+
+// Canonical returns the canonical form of the address, which is the same for
+// every address delivered to the same mailbox.
+//
+// The address is lowercased. For known providers that ignore dots or +tag
+// suffixes in the local part, those are removed as well.
+func (a EmailAddress) Canonical() EmailAddress {
+	local, domain := strings.ToLower(a.LocalPart()), canonicalHost(a.Domain())
+
+	if rule, ok := providerRules[domain]; ok && !strings.HasPrefix(local, `"`) {
+		if rule.tagSeparator != "" {
+			if i := strings.Index(local, rule.tagSeparator); i > 0 {
+				local = local[:i]
+			}
+		}
+
+		if rule.ignoreDots {
+			local = strings.Replace(local, ".", "", -1)
+		}
+
+		domain = rule.domain
+	}
+
+	return EmailAddress(local + "@" + domain)
+}
+
+// canonicalDomain returns the domain that the addresses of a domain have in
+// their canonical form.
+func canonicalDomain(domain string) string {
+	domain = canonicalHost(domain)
+
+	if rule, ok := providerRules[domain]; ok {
+		return rule.domain
+	}
+
+	return domain
+}
+
+// canonicalHost normalizes a domain that may not have been parsed, falling back
+// to lowercasing it when it is not valid.
+func canonicalHost(domain string) string {
+	if normalized, err := normalizeDomain(domain); err == nil {
+		return normalized
+	}
+
+	return strings.ToLower(domain)
+}
+
+func validateLocalPart(local string) error {
+	switch {
+	case local == "":
+		return fmt.Errorf("%w: empty local part", ErrInvalidEmailAddress)
+	case len(local) > maxLocalPartLength:
+		return fmt.Errorf("%w: local part too long", ErrInvalidEmailAddress)
+	case !utf8.ValidString(local):
+		return fmt.Errorf("%w: local part is not valid UTF-8", ErrInvalidEmailAddress)
+	}
+
+	if strings.HasPrefix(local, `"`) {
+		return validateQuotedString(local)
+	}
+
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" {
+			return fmt.Errorf("%w: misplaced dot in local part", ErrInvalidEmailAddress)
+		}
+
+		for _, r := range atom {
+			if !isAtext(r) {
+				return fmt.Errorf("%w: invalid character %q in local part", ErrInvalidEmailAddress, r)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateQuotedString(s string) error {
+	if len(s) < 2 || !strings.HasSuffix(s, `"`) {
+		return fmt.Errorf("%w: unterminated quoted local part", ErrInvalidEmailAddress)
+	}
+
+	escaped := false
+
+	for _, r := range s[1 : len(s)-1] {
+		switch {
+		case escaped:
+			if r < ' ' || r == 0x7f {
+				return fmt.Errorf("%w: invalid quoted pair in local part", ErrInvalidEmailAddress)
+			}
+
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"' || r < ' ' || r == 0x7f:
+			return fmt.Errorf("%w: invalid character %q in quoted local part", ErrInvalidEmailAddress, r)
+		}
+	}
+
+	if escaped {
+		return fmt.Errorf("%w: unterminated quoted pair in local part", ErrInvalidEmailAddress)
+	}
+
+	return nil
+}
+
+// isAtext reports whether r is an atext character of RFC 5322, extended with
+// the UTF-8 characters of RFC 6532.
+func isAtext(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	case r >= utf8.RuneSelf:
+		return true
+	}
+
+	return strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", r)
+}
+
+// normalizeDomain maps a domain for lookup and converts internationalized
+// labels to punycode, which also lowercases the domain and normalizes it to
+// NFC.
+func normalizeDomain(domain string) (string, error) {
+	if strings.HasPrefix(domain, "[") && strings.HasSuffix(domain, "]") {
+		return normalizeAddressLiteral(domain)
+	}
+
+	if !utf8.ValidString(domain) {
+		return "", fmt.Errorf("%w: domain is not valid UTF-8", ErrInvalidEmailAddress)
+	}
+
+	domain, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidEmailAddress, err)
+	}
+
+	domain = strings.TrimSuffix(domain, ".")
+
+	labels := strings.Split(domain, ".")
+
+	if len(labels) < 2 {
+		return "", fmt.Errorf("%w: domain is not fully qualified", ErrInvalidEmailAddress)
+	}
+
+	for _, label := range labels {
+		if err := checkDomainLabel(label); err != nil {
+			return "", err
+		}
+	}
+
+	if isNumeric(labels[len(labels)-1]) {
+		return "", fmt.Errorf("%w: numeric top-level domain", ErrInvalidEmailAddress)
+	}
+
+	domain = strings.Join(labels, ".")
+
+	if len(domain) > maxDomainLength {
+		return "", fmt.Errorf("%w: domain too long", ErrInvalidEmailAddress)
+	}
+
+	return domain, nil
+}
+
+func normalizeAddressLiteral(domain string) (string, error) {
+	literal := domain[1 : len(domain)-1]
+
+	if strings.HasPrefix(strings.ToLower(literal), "ipv6:") {
+		if ip := net.ParseIP(literal[5:]); ip != nil && ip.To4() == nil {
+			return "[IPv6:" + ip.String() + "]", nil
+		}
+	} else if ip := net.ParseIP(literal); ip != nil && ip.To4() != nil {
+		return "[" + ip.String() + "]", nil
+	}
+
+	return "", fmt.Errorf("%w: invalid address literal", ErrInvalidEmailAddress)
+}
+
+// checkDomainLabel checks a domain label that has been converted to ASCII.
+func checkDomainLabel(label string) error {
+	if label == "" {
+		return fmt.Errorf("%w: empty domain label", ErrInvalidEmailAddress)
+	}
+
+	if len(label) > maxDomainLabelLength {
+		return fmt.Errorf("%w: domain label too long", ErrInvalidEmailAddress)
+	}
+
+	if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+		return fmt.Errorf("%w: misplaced hyphen in domain", ErrInvalidEmailAddress)
+	}
+
+	for _, r := range label {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+			return fmt.Errorf("%w: invalid character %q in domain", ErrInvalidEmailAddress, r)
+		}
+	}
+
+	return nil
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "testing"
+
+func TestEmailAddressCanonicalInternationalizedDomains(t *testing.T) {
+	const want = EmailAddress("jane@xn--mnchen-3ya.de")
+
+	tests := []struct {
+		name string
+		addr string
+	}{
+		{name: "composed", addr: "jane@münchen.de"},
+		{name: "decomposed", addr: "jane@münchen.de"},
+		{name: "mixed case composed", addr: "Jane@MÜnchen.DE"},
+		{name: "mixed case decomposed", addr: "Jane@MÜNCHEN.de"},
+		{name: "punycode", addr: "jane@xn--mnchen-3ya.de"},
+		{name: "upper case punycode", addr: "jane@XN--MNCHEN-3YA.DE"},
+		{name: "ideographic full stop", addr: "jane@münchen。de"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := ParseEmailAddress(tt.addr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := addr.Canonical(); got != want {
+				t.Errorf("canonical form of %q is %q, want %q", tt.addr, got, want)
+			}
+
+			if got := EmailAddress(tt.addr).Canonical(); got != want {
+				t.Errorf("canonical form of unparsed %q is %q, want %q", tt.addr, got, want)
+			}
+		})
+	}
+}
+
+func TestParseEmailAddressRejectsInvalidDomains(t *testing.T) {
+	for _, addr := range []string{
+		"jane@xn--munchen-gie.de",
+		"jane@-example.com",
+		"jane@ex_ample.com",
+		"jane@example",
+		"jane@example..com",
+	} {
+		if _, err := ParseEmailAddress(addr); err == nil {
+			t.Errorf("parsed %q, want an error", addr)
+		}
+	}
+}
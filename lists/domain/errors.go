@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Error classes. Every error returned for a failed command or query matches
+// at most one of them with errors.Is, so that callers can map errors to status
+// codes without knowing every error.
+var (
+	// ErrNotFound is returned when an entity does not exist or belongs to
+	// another organization.
+	ErrNotFound = errors.New("not found")
+
+	// ErrVersionConflict is returned when saving an entity that has been
+	// changed since it was read.
+	ErrVersionConflict = errors.New("version conflict")
+
+	// ErrDuplicate is returned when creating an entity that exists already.
+	ErrDuplicate = errors.New("duplicate")
+
+	// ErrInvariant is matched by every error of a command that would break a
+	// business rule.
+	ErrInvariant = errors.New("invariant error")
+
+	// ErrValidation is matched by every error of input that is not valid.
+	ErrValidation = errors.New("validation error")
+)
+
+// InvariantError is returned when a command would break a business rule. It
+// matches ErrInvariant.
+type InvariantError struct {
+	Reason string
+}
+
+func (e *InvariantError) Error() string {
+	return "invariant error: " + e.Reason
+}
+
+// Is reports whether the target is ErrInvariant.
+func (e *InvariantError) Is(target error) bool {
+	return target == ErrInvariant
+}
+
+// ValidationError is returned for input that is not valid. It matches
+// ErrValidation.
+type ValidationError struct {
+	Message string
+	// Fields holds the problems of the input per field, for input that has
+	// fields.
+	Fields map[string]error
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+
+	return e.Message + ": " + validation.Errors(e.Fields).Error()
+}
+
+// Is reports whether the target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// validationError returns the errors of a validation as a ValidationError.
+// Other errors are returned as they are. The problems of nested values are kept
+// in the form of the validation.
+func validationError(err error) error {
+	errs, ok := err.(validation.Errors)
+	if !ok {
+		return err
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	fields := map[string]error{}
+
+	for name, err := range errs {
+		if v, ok := err.(*ValidationError); ok && len(v.Fields) > 0 {
+			err = validation.Errors(v.Fields)
+		}
+
+		fields[name] = err
+	}
+
+	return &ValidationError{
+		Message: "validation failed",
+		Fields:  fields,
+	}
+}
@@ -9,26 +9,32 @@ import (
 
 // List is a subscriber list.
 type List struct {
-	PK      uuid.UUID
-	Title   string
-	Version uint32
+	PK             uuid.UUID
+	OrganizationPK uuid.UUID
+	Title          string
+	Schema         Schema
+	Version        uint32
 }
 
 // Validate the subscriber list.
 func (l *List) Validate() error {
-	return validation.ValidateStruct(l,
+	return validationError(validation.ValidateStruct(l,
 		validation.Field(&l.PK, validation.Required),
+		validation.Field(&l.OrganizationPK, validation.Required),
 		validation.Field(&l.Title, validation.Required),
+		validation.Field(&l.Schema),
 		validation.Field(&l.Version, validation.Required),
-	)
+	))
 }
 
 // CreateList creates a subscriber list.
-func CreateList(title string) (*List, error) {
+func CreateList(org Organization, title string) (*List, error) {
 	list := &List{
-		PK:      uuid.Must(uuid.NewV4()),
-		Title:   title,
-		Version: 1,
+		PK:             uuid.Must(uuid.NewV4()),
+		OrganizationPK: org.PK,
+		Title:          title,
+		Schema:         Schema{Version: 1},
+		Version:        1,
 	}
 
 	if err := list.Validate(); err != nil {
@@ -49,3 +55,19 @@ func RenameList(list List, title string) (*List, error) {
 
 	return &list, nil
 }
+
+// ChangeListSchema replaces the custom field schema of a subscriber list with a
+// new version of it.
+func ChangeListSchema(list List, fields []Field) (*List, error) {
+	list.Schema = Schema{
+		Version: list.Schema.Version + 1,
+		Fields:  fields,
+	}
+	list.Version++
+
+	if err := list.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+)
+
+// Organization is a tenant owning subscriber lists and subscribers.
+type Organization struct {
+	PK      uuid.UUID
+	Name    string
+	Version uint32
+}
+
+// Validate the organization.
+func (o *Organization) Validate() error {
+	return validationError(validation.ValidateStruct(o,
+		validation.Field(&o.PK, validation.Required),
+		validation.Field(&o.Name, validation.Required),
+		validation.Field(&o.Version, validation.Required),
+	))
+}
+
+// CreateOrganization creates an organization.
+func CreateOrganization(name string) (*Organization, error) {
+	org := &Organization{
+		PK:      uuid.Must(uuid.NewV4()),
+		Name:    strings.TrimSpace(name),
+		Version: 1,
+	}
+
+	if err := org.Validate(); err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+// RenameOrganization renames an organization.
+func RenameOrganization(org Organization, name string) (*Organization, error) {
+	org.Name = strings.TrimSpace(name)
+	org.Version++
+
+	if err := org.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &org, nil
+}
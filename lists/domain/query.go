@@ -0,0 +1,197 @@
+package domain
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+)
+
+// Page size bounds.
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 1000
+)
+
+// ErrInvalidCursor is returned when a page cursor cannot be decoded or was
+// issued for a different sort order.
+var ErrInvalidCursor error = &ValidationError{Message: "invalid cursor"}
+
+// SortDirection is the direction of a sort order.
+type SortDirection string
+
+// Sort directions.
+const (
+	SortAscending  SortDirection = "asc"
+	SortDescending SortDirection = "desc"
+)
+
+// PageRequest requests a page of results.
+type PageRequest struct {
+	// Cursor continues from the end of a previous page. An empty cursor
+	// starts from the first page.
+	Cursor string
+	// Limit is the maximum number of results. Zero means DefaultPageLimit.
+	Limit uint32
+	// WithTotal requests the total number of results matching the filters.
+	WithTotal bool
+}
+
+// PageLimit returns the effective page limit.
+func (r PageRequest) PageLimit() uint32 {
+	if r.Limit == 0 {
+		return DefaultPageLimit
+	}
+
+	return r.Limit
+}
+
+// Validate the page request.
+func (r PageRequest) Validate() error {
+	return validationError(validation.ValidateStruct(&r,
+		validation.Field(&r.Limit, validation.Max(uint32(MaxPageLimit))),
+	))
+}
+
+// PageInfo describes a returned page.
+type PageInfo struct {
+	// NextCursor continues from the end of the page. It is empty on the last
+	// page.
+	NextCursor string
+	// Total is the number of results matching the filters. It is only set
+	// when requested.
+	Total *uint64
+}
+
+// ListSortField is a field subscriber lists can be sorted by.
+type ListSortField string
+
+// Subscriber list sort fields.
+const (
+	ListSortByTitle ListSortField = "title"
+)
+
+// ListQuery filters and sorts subscriber lists.
+type ListQuery struct {
+	// TitleContains matches lists whose title contains the substring,
+	// ignoring case.
+	TitleContains string
+	SortBy        ListSortField
+	Direction     SortDirection
+	PageRequest
+}
+
+// Validate the subscriber list query.
+func (q ListQuery) Validate() error {
+	return validationError(validation.ValidateStruct(&q,
+		validation.Field(&q.SortBy, validation.In(ListSortByTitle)),
+		validation.Field(&q.Direction, validation.In(SortAscending, SortDescending)),
+		validation.Field(&q.PageRequest),
+	))
+}
+
+// ListPage is a page of subscriber lists.
+type ListPage struct {
+	Lists []*List
+	PageInfo
+}
+
+// SubscriberSortField is a field subscribers can be sorted by.
+type SubscriberSortField string
+
+// Subscriber sort fields.
+const (
+	SubscriberSortByEmailAddress SubscriberSortField = "email"
+)
+
+// SubscriberQuery filters and sorts subscribers.
+type SubscriberQuery struct {
+	SortBy    SubscriberSortField
+	Direction SortDirection
+	PageRequest
+}
+
+// Validate the subscriber query.
+func (q SubscriberQuery) Validate() error {
+	return validationError(validation.ValidateStruct(&q,
+		validation.Field(&q.SortBy, validation.In(SubscriberSortByEmailAddress)),
+		validation.Field(&q.Direction, validation.In(SortAscending, SortDescending)),
+		validation.Field(&q.PageRequest),
+	))
+}
+
+// SubscriberPage is a page of subscribers.
+type SubscriberPage struct {
+	Subscribers []*Subscriber
+	PageInfo
+}
+
+// SubscriptionSortField is a field subscriptions can be sorted by.
+type SubscriptionSortField string
+
+// Subscription sort fields.
+const (
+	SubscriptionSortByEmailAddress   SubscriptionSortField = "email"
+	SubscriptionSortByState          SubscriptionSortField = "state"
+	SubscriptionSortByStateChangedAt SubscriptionSortField = "state_changed_at"
+)
+
+// SubscriptionFilter filters subscriptions.
+type SubscriptionFilter struct {
+	// ListPK matches subscriptions to a list when set.
+	ListPK uuid.UUID
+	// SubscriberPK matches subscriptions of a subscriber when set.
+	SubscriberPK uuid.UUID
+	// States matches subscriptions in any of the states when set.
+	States []SubscriptionState
+}
+
+// SubscriptionQuery filters and sorts subscriptions.
+type SubscriptionQuery struct {
+	SubscriptionFilter
+	SortBy    SubscriptionSortField
+	Direction SortDirection
+	PageRequest
+}
+
+// Validate the subscription query.
+func (q SubscriptionQuery) Validate() error {
+	return validationError(validation.ValidateStruct(&q,
+		validation.Field(&q.SortBy, validation.In(
+			SubscriptionSortByEmailAddress,
+			SubscriptionSortByState,
+			SubscriptionSortByStateChangedAt,
+		)),
+		validation.Field(&q.Direction, validation.In(SortAscending, SortDescending)),
+		validation.Field(&q.PageRequest),
+	))
+}
+
+// SubscriptionPage is a page of subscriptions.
+type SubscriptionPage struct {
+	Subscriptions []*Subscription
+	PageInfo
+}
+
+// SuppressionQuery filters suppressions. Suppressions are ordered by the time
+// they were created.
+type SuppressionQuery struct {
+	// Reasons matches suppressions with any of the reasons when set.
+	Reasons []SuppressionReason
+	// IncludeExpired includes suppressions that have lapsed.
+	IncludeExpired bool
+	Direction      SortDirection
+	PageRequest
+}
+
+// Validate the suppression query.
+func (q SuppressionQuery) Validate() error {
+	return validationError(validation.ValidateStruct(&q,
+		validation.Field(&q.Direction, validation.In(SortAscending, SortDescending)),
+		validation.Field(&q.PageRequest),
+	))
+}
+
+// SuppressionPage is a page of suppressions.
+type SuppressionPage struct {
+	Suppressions []*Suppression
+	PageInfo
+}
@@ -0,0 +1,367 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// DateLayout is the layout date field values are stored in.
+const DateLayout = "2006-01-02"
+
+// FieldType is the type of a custom subscription field.
+type FieldType string
+
+// Custom subscription field types.
+const (
+	FieldString      FieldType = "string"
+	FieldNumber      FieldType = "number"
+	FieldBoolean     FieldType = "boolean"
+	FieldDate        FieldType = "date"
+	FieldEnum        FieldType = "enum"
+	FieldMultiSelect FieldType = "multi_select"
+)
+
+// Field is a custom subscription field of a list.
+type Field struct {
+	Name     string      `json:"name"`
+	Type     FieldType   `json:"type"`
+	Required bool        `json:"required,omitempty"`
+	Default  interface{} `json:"default,omitempty"`
+
+	// Options lists the allowed values of enum and multi-select fields.
+	Options []string `json:"options,omitempty"`
+	// MinLength and MaxLength constrain the length of string fields.
+	MinLength *int `json:"min_length,omitempty"`
+	MaxLength *int `json:"max_length,omitempty"`
+	// Min and Max constrain the value of number fields.
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+// Validate the field definition.
+func (f Field) Validate() error {
+	if err := validation.ValidateStruct(&f,
+		validation.Field(&f.Name, validation.Required, validation.Length(1, 64)),
+		validation.Field(&f.Type, validation.Required, validation.In(
+			FieldString,
+			FieldNumber,
+			FieldBoolean,
+			FieldDate,
+			FieldEnum,
+			FieldMultiSelect,
+		)),
+		validation.Field(&f.Options, validation.When(
+			f.Type == FieldEnum || f.Type == FieldMultiSelect,
+			validation.Required,
+		).Else(validation.Empty)),
+	); err != nil {
+		return validationError(err)
+	}
+
+	if f.Default != nil {
+		if _, err := f.coerce(f.Default); err != nil {
+			return validationError(validation.Errors{"Default": err})
+		}
+	}
+
+	return nil
+}
+
+// coerce converts a value to the field type and checks its constraints.
+func (f Field) coerce(v interface{}) (interface{}, error) {
+	switch f.Type {
+	case FieldString:
+		return f.coerceString(v)
+	case FieldNumber:
+		return f.coerceNumber(v)
+	case FieldBoolean:
+		return coerceBoolean(v)
+	case FieldDate:
+		return coerceDate(v)
+	case FieldEnum:
+		return f.coerceEnum(v)
+	case FieldMultiSelect:
+		return f.coerceMultiSelect(v)
+	}
+
+	return nil, fmt.Errorf("unsupported field type %q", f.Type)
+}
+
+func (f Field) coerceString(v interface{}) (interface{}, error) {
+	var s string
+
+	switch v := v.(type) {
+	case string:
+		s = v
+	case bool, float64, float32, int, int32, int64, uint, uint32, uint64, json.Number:
+		s = fmt.Sprint(v)
+	default:
+		return nil, errors.New("must be a string")
+	}
+
+	n := len([]rune(s))
+
+	if f.MinLength != nil && n < *f.MinLength {
+		return nil, fmt.Errorf("must be at least %d characters long", *f.MinLength)
+	}
+
+	if f.MaxLength != nil && n > *f.MaxLength {
+		return nil, fmt.Errorf("must be at most %d characters long", *f.MaxLength)
+	}
+
+	return s, nil
+}
+
+func (f Field) coerceNumber(v interface{}) (interface{}, error) {
+	var (
+		n   float64
+		err error
+	)
+
+	switch v := v.(type) {
+	case float64:
+		n = v
+	case float32:
+		n = float64(v)
+	case int:
+		n = float64(v)
+	case int32:
+		n = float64(v)
+	case int64:
+		n = float64(v)
+	case uint:
+		n = float64(v)
+	case uint32:
+		n = float64(v)
+	case uint64:
+		n = float64(v)
+	case json.Number:
+		n, err = v.Float64()
+	case string:
+		n, err = strconv.ParseFloat(strings.TrimSpace(v), 64)
+	default:
+		err = errors.New("not a number")
+	}
+
+	if err != nil {
+		return nil, errors.New("must be a number")
+	}
+
+	if f.Min != nil && n < *f.Min {
+		return nil, fmt.Errorf("must be no less than %v", *f.Min)
+	}
+
+	if f.Max != nil && n > *f.Max {
+		return nil, fmt.Errorf("must be no greater than %v", *f.Max)
+	}
+
+	return n, nil
+}
+
+func coerceBoolean(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "yes", "1":
+			return true, nil
+		case "false", "no", "0":
+			return false, nil
+		}
+	}
+
+	return nil, errors.New("must be a boolean")
+}
+
+func coerceDate(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case time.Time:
+		return v.Format(DateLayout), nil
+	case string:
+		v = strings.TrimSpace(v)
+
+		if t, err := time.Parse(DateLayout, v); err == nil {
+			return t.Format(DateLayout), nil
+		}
+
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t.Format(DateLayout), nil
+		}
+	}
+
+	return nil, fmt.Errorf("must be a date in %s format", DateLayout)
+}
+
+func (f Field) coerceEnum(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok || !f.hasOption(s) {
+		return nil, fmt.Errorf("must be one of %s", strings.Join(f.Options, ", "))
+	}
+
+	return s, nil
+}
+
+func (f Field) coerceMultiSelect(v interface{}) (interface{}, error) {
+	var values []string
+
+	switch v := v.(type) {
+	case []string:
+		values = v
+	case []interface{}:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, errors.New("must be a list of strings")
+			}
+
+			values = append(values, s)
+		}
+	case string:
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+	default:
+		return nil, errors.New("must be a list of strings")
+	}
+
+	res := []string{}
+
+	for _, s := range values {
+		if !f.hasOption(s) {
+			return nil, fmt.Errorf("must only contain %s", strings.Join(f.Options, ", "))
+		}
+
+		res = append(res, s)
+	}
+
+	return res, nil
+}
+
+func (f Field) hasOption(s string) bool {
+	for _, option := range f.Options {
+		if option == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Schema is the versioned custom field schema of a list.
+//
+// A list without fields accepts any subscription data.
+type Schema struct {
+	Version uint32  `json:"version"`
+	Fields  []Field `json:"fields"`
+}
+
+// Validate the schema.
+func (s Schema) Validate() error {
+	names := map[string]bool{}
+
+	for i, field := range s.Fields {
+		if err := field.Validate(); err != nil {
+			return validationError(validation.Errors{fmt.Sprintf("Fields[%d]", i): err})
+		}
+
+		if names[field.Name] {
+			return validationError(validation.Errors{fmt.Sprintf("Fields[%d]", i): fmt.Errorf("duplicate field %q", field.Name)})
+		}
+
+		names[field.Name] = true
+	}
+
+	return nil
+}
+
+// Apply validates subscription data against the schema and coerces its values
+// to the field types.
+//
+// Missing fields are set to their default values. Fields not in the schema are
+// rejected. The returned error is a ValidationError with the problems keyed by
+// field name.
+func (s Schema) Apply(data map[string]interface{}) (map[string]interface{}, error) {
+	if len(s.Fields) == 0 {
+		return data, nil
+	}
+
+	res := map[string]interface{}{}
+	errs := validation.Errors{}
+
+	for _, field := range s.Fields {
+		v, ok := data[field.Name]
+		if !ok || v == nil || v == "" {
+			switch {
+			case field.Default != nil:
+				v = field.Default
+			case field.Required:
+				errs[field.Name] = errors.New("cannot be blank")
+				continue
+			default:
+				continue
+			}
+		}
+
+		coerced, err := field.coerce(v)
+		if err != nil {
+			errs[field.Name] = err
+			continue
+		}
+
+		res[field.Name] = coerced
+	}
+
+	for name := range data {
+		if !s.hasField(name) {
+			errs[name] = errors.New("is not a field of the list")
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, validationError(errs)
+	}
+
+	return res, nil
+}
+
+// Migrate converts subscription data of an earlier schema version to the
+// schema. Fields that have been removed from the schema are dropped.
+func (s Schema) Migrate(data map[string]interface{}) (map[string]interface{}, error) {
+	return s.Apply(s.retain(data))
+}
+
+// retain returns the subscription data without fields not in the schema.
+func (s Schema) retain(data map[string]interface{}) map[string]interface{} {
+	if len(s.Fields) == 0 {
+		return data
+	}
+
+	res := map[string]interface{}{}
+
+	for name, v := range data {
+		if s.hasField(name) {
+			res[name] = v
+		}
+	}
+
+	return res
+}
+
+func (s Schema) hasField(name string) bool {
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// MaxStatsDays is the longest range of days daily statistics are returned
+// for at once.
+const MaxStatsDays = 366
+
+// ErrInvalidStatsRange is returned for a range of days that ends before it
+// starts or is longer than MaxStatsDays.
+var ErrInvalidStatsRange error = &ValidationError{Message: "invalid statistics range"}
+
+// ListCounts are the numbers of subscriptions to a list by state.
+type ListCounts struct {
+	ListPK  uuid.UUID
+	Active  uint64
+	Pending uint64
+	// Cancelled counts unsubscribed, bounced, complained and suppressed
+	// subscriptions.
+	Cancelled uint64
+}
+
+// DailyListStats are the numbers of subscriptions to a list that became
+// active and that were unsubscribed on a day, in UTC.
+type DailyListStats struct {
+	ListPK  uuid.UUID
+	Day     time.Time
+	OptIns  uint64
+	OptOuts uint64
+}
+
+// StatsDay returns the day in UTC the daily statistics of a time fall on.
+func StatsDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// IsCancelled reports whether a subscription in the state has been stopped
+// without being forgotten.
+func (s SubscriptionState) IsCancelled() bool {
+	switch s {
+	case SubscriptionUnsubscribed, SubscriptionBounced, SubscriptionComplained, SubscriptionSuppressed:
+		return true
+	}
+
+	return false
+}
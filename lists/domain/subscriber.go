@@ -1,24 +1,40 @@
 package domain
 
-import "github.com/gofrs/uuid"
+import (
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+)
 
 // Subscriber is an entity subscribed to a list.
 type Subscriber struct {
-	PK           uuid.UUID
-	EmailAddress EmailAddress
-	Version      uint32
+	PK             uuid.UUID
+	OrganizationPK uuid.UUID
+	EmailAddress   EmailAddress
+	// SoftBounces is the number of soft bounces since the subscriber's last
+	// hard bounce or successful delivery.
+	SoftBounces uint32
+	Version     uint32
 }
 
 // Validate the subscriber.
 func (s *Subscriber) Validate() error {
-	return nil
+	return validationError(validation.ValidateStruct(s,
+		validation.Field(&s.PK, validation.Required),
+		validation.Field(&s.OrganizationPK, validation.Required),
+		validation.Field(&s.EmailAddress),
+		validation.Field(&s.Version, validation.Required),
+	))
 }
 
 // CreateSubscriber creates a subscriber.
-func CreateSubscriber(addr EmailAddress) (*Subscriber, error) {
+func CreateSubscriber(org Organization, addr EmailAddress) (*Subscriber, error) {
 	s := &Subscriber{
-		PK:           uuid.Must(uuid.NewV4()),
-		EmailAddress: addr,
+		PK:             uuid.Must(uuid.NewV4()),
+		OrganizationPK: org.PK,
+		EmailAddress:   addr,
+		Version:        1,
 	}
 
 	if err := s.Validate(); err != nil {
@@ -29,8 +45,32 @@ func CreateSubscriber(addr EmailAddress) (*Subscriber, error) {
 }
 
 // ForgetSubscriber forgets a subscriber.
+//
+// The email address is replaced with a tombstone that is unique to the
+// subscriber, so that forgotten subscribers never collide with each other.
 func ForgetSubscriber(s Subscriber) (*Subscriber, error) {
-	s.EmailAddress = "forgotten@smaily.email"
+	s.EmailAddress = forgottenEmailAddress(s.PK)
+	s.Version++
+
+	return &s, nil
+}
+
+func forgottenEmailAddress(pk uuid.UUID) EmailAddress {
+	return EmailAddress(fmt.Sprintf("forgotten-%s@smaily.email", pk))
+}
+
+// RecordSoftBounce counts a temporary delivery failure to a subscriber.
+func RecordSoftBounce(s Subscriber) (*Subscriber, error) {
+	s.SoftBounces++
+	s.Version++
+
+	return &s, nil
+}
+
+// ResetSoftBounces clears the soft bounce count of a subscriber.
+func ResetSoftBounces(s Subscriber) (*Subscriber, error) {
+	s.SoftBounces = 0
+	s.Version++
 
 	return &s, nil
 }
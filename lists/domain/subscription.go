@@ -1,34 +1,339 @@
 package domain
 
 import (
-	"errors"
+	"fmt"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
 
+// SubscriptionState is the lifecycle state of a subscription.
+type SubscriptionState string
+
+// Subscription lifecycle states.
+const (
+	SubscriptionPending      SubscriptionState = "pending"
+	SubscriptionActive       SubscriptionState = "active"
+	SubscriptionUnsubscribed SubscriptionState = "unsubscribed"
+	SubscriptionBounced      SubscriptionState = "bounced"
+	SubscriptionComplained   SubscriptionState = "complained"
+	SubscriptionSuppressed   SubscriptionState = "suppressed"
+	SubscriptionForgotten    SubscriptionState = "forgotten"
+)
+
+// subscriptionTransitions lists the states a subscription may move to from
+// each state.
+var subscriptionTransitions = map[SubscriptionState][]SubscriptionState{
+	SubscriptionPending: {
+		SubscriptionActive,
+		SubscriptionUnsubscribed,
+		SubscriptionSuppressed,
+		SubscriptionForgotten,
+	},
+	SubscriptionActive: {
+		SubscriptionUnsubscribed,
+		SubscriptionBounced,
+		SubscriptionComplained,
+		SubscriptionSuppressed,
+		SubscriptionForgotten,
+	},
+	SubscriptionUnsubscribed: {
+		SubscriptionPending,
+		SubscriptionActive,
+		SubscriptionComplained,
+		SubscriptionSuppressed,
+		SubscriptionForgotten,
+	},
+	SubscriptionBounced: {
+		SubscriptionSuppressed,
+		SubscriptionForgotten,
+	},
+	SubscriptionComplained: {
+		SubscriptionSuppressed,
+		SubscriptionForgotten,
+	},
+	// A suppressed subscription is resubscribed once its suppression has
+	// been lifted.
+	SubscriptionSuppressed: {
+		SubscriptionPending,
+		SubscriptionActive,
+		SubscriptionForgotten,
+	},
+	SubscriptionForgotten: {},
+}
+
+// CanTransition reports whether a subscription may move from one state to
+// another.
+func (s SubscriptionState) CanTransition(to SubscriptionState) bool {
+	for _, state := range subscriptionTransitions[s] {
+		if state == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+var (
+	// ErrOrganizationMismatch is returned when entities of different
+	// organizations are combined.
+	ErrOrganizationMismatch error = &InvariantError{Reason: "organization mismatch"}
+
+	// ErrListMismatch is returned when a subscription is changed according to
+	// a list it does not belong to.
+	ErrListMismatch error = &InvariantError{Reason: "list mismatch"}
+
+	// ErrSubscriberMismatch is returned when a subscription is changed on
+	// behalf of a subscriber it does not belong to.
+	ErrSubscriberMismatch error = &InvariantError{Reason: "subscriber mismatch"}
+
+	// ErrAlreadySubscribed is returned when subscribing a subscriber who
+	// already has an active subscription to the list.
+	ErrAlreadySubscribed error = &InvariantError{Reason: "already subscribed"}
+
+	// ErrNotSubscribed is returned when changing a subscription that is
+	// neither active nor pending.
+	ErrNotSubscribed error = &InvariantError{Reason: "not subscribed"}
+
+	// ErrConfirmationMismatch is returned when a confirmation does not belong
+	// to the subscription being confirmed.
+	ErrConfirmationMismatch error = &InvariantError{Reason: "confirmation mismatch"}
+
+	// ErrConfirmationExpired is returned when confirming a subscription with
+	// an expired confirmation.
+	ErrConfirmationExpired error = &InvariantError{Reason: "confirmation expired"}
+
+	// ErrConfirmationNotExpired is returned when expiring a subscription whose
+	// confirmation is still valid.
+	ErrConfirmationNotExpired error = &InvariantError{Reason: "confirmation not expired"}
+)
+
+// TransitionError is returned when a subscription cannot move from its current
+// state to the requested one.
+type TransitionError struct {
+	From SubscriptionState
+	To   SubscriptionState
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("invariant error: subscription cannot transition from %s to %s", e.From, e.To)
+}
+
+// Is reports whether the target is ErrInvariant.
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvariant
+}
+
 // Subscription is a subscriber's subscription to a list.
 type Subscription struct {
-	PK           uuid.UUID
-	SubscriberPK uuid.UUID
-	ListPK       uuid.UUID
-	EmailAddress EmailAddress
-	Data         map[string]interface{}
-	IsCancelled  bool
-	Version      uint32
+	PK             uuid.UUID
+	OrganizationPK uuid.UUID
+	SubscriberPK   uuid.UUID
+	ListPK         uuid.UUID
+	EmailAddress   EmailAddress
+	Data           map[string]interface{}
+	SchemaVersion  uint32
+	State          SubscriptionState
+	StateChangedAt time.Time
+	Version        uint32
+}
+
+// CreateSubscription creates an active subscription.
+func CreateSubscription(subscriber Subscriber, list List, data map[string]interface{}) (*Subscription, error) {
+	return newSubscription(subscriber, list, data, SubscriptionActive)
+}
+
+// CreatePendingSubscription creates a subscription awaiting confirmation.
+func CreatePendingSubscription(subscriber Subscriber, list List, data map[string]interface{}) (*Subscription, error) {
+	return newSubscription(subscriber, list, data, SubscriptionPending)
+}
+
+func newSubscription(subscriber Subscriber, list List, data map[string]interface{}, state SubscriptionState) (*Subscription, error) {
+	if subscriber.OrganizationPK != list.OrganizationPK {
+		return nil, ErrOrganizationMismatch
+	}
+
+	data, err := list.Schema.Apply(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Subscription{
+		PK:             uuid.Must(uuid.NewV4()),
+		OrganizationPK: list.OrganizationPK,
+		SubscriberPK:   subscriber.PK,
+		ListPK:         list.PK,
+		EmailAddress:   subscriber.EmailAddress,
+		Data:           data,
+		SchemaVersion:  list.Schema.Version,
+		State:          state,
+		StateChangedAt: time.Now(),
+		Version:        1,
+	}, nil
+}
+
+// ResubscribeSubscription reactivates a subscription the subscriber has
+// previously cancelled, merging the new data into the existing data.
+//
+// A pending subscription may be resubscribed as pending again, which only
+// merges the data. The subscription moves to the given state, which is either
+// active or pending.
+func ResubscribeSubscription(subscription Subscription, list List, state SubscriptionState, data map[string]interface{}, rule DataMergeRule, now time.Time) (*Subscription, error) {
+	if subscription.ListPK != list.PK {
+		return nil, ErrListMismatch
+	}
+
+	switch {
+	case subscription.State == SubscriptionActive && (state == SubscriptionActive || state == SubscriptionPending):
+		return nil, ErrAlreadySubscribed
+	case state != SubscriptionActive && state != SubscriptionPending:
+		return nil, &TransitionError{From: subscription.State, To: state}
+	}
+
+	data, err := list.Schema.Apply(MergeSubscriptionData(list.Schema.retain(subscription.Data), data, rule))
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Data = data
+	subscription.SchemaVersion = list.Schema.Version
+
+	if subscription.State == SubscriptionPending && state == SubscriptionPending {
+		subscription.Version++
+
+		return &subscription, nil
+	}
+
+	return transitionSubscription(subscription, state, now)
+}
+
+// ChangeSubscriptionData merges new data into the data of an active or pending
+// subscription.
+func ChangeSubscriptionData(subscription Subscription, list List, data map[string]interface{}, rule DataMergeRule) (*Subscription, error) {
+	if subscription.ListPK != list.PK {
+		return nil, ErrListMismatch
+	}
+
+	if subscription.State != SubscriptionActive && subscription.State != SubscriptionPending {
+		return nil, ErrNotSubscribed
+	}
+
+	data, err := list.Schema.Apply(MergeSubscriptionData(list.Schema.retain(subscription.Data), data, rule))
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Data = data
+	subscription.SchemaVersion = list.Schema.Version
+	subscription.Version++
+
+	return &subscription, nil
+}
+
+// MigrateSubscription migrates the data of a subscription to the current
+// schema version of its list.
+func MigrateSubscription(subscription Subscription, list List) (*Subscription, error) {
+	if subscription.ListPK != list.PK {
+		return nil, ErrListMismatch
+	}
+
+	if subscription.State == SubscriptionForgotten {
+		return nil, &TransitionError{From: subscription.State, To: subscription.State}
+	}
+
+	data, err := list.Schema.Migrate(subscription.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Data = data
+	subscription.SchemaVersion = list.Schema.Version
+	subscription.Version++
+
+	return &subscription, nil
+}
+
+// ConfirmSubscription activates a pending subscription.
+func ConfirmSubscription(subscription Subscription, confirmation Confirmation, now time.Time) (*Subscription, error) {
+	if subscription.PK != confirmation.SubscriptionPK {
+		return nil, ErrConfirmationMismatch
+	}
+
+	if confirmation.IsExpired(now) {
+		return nil, ErrConfirmationExpired
+	}
+
+	return transitionSubscription(subscription, SubscriptionActive, now)
 }
 
-// CreateSubscription creates a subscription.
-func CreateSubscription(Subscriber, List, map[string]interface{}) (*Subscription, error) {
-	return nil, nil
+// ExpireSubscription expires a pending subscription that was not confirmed in
+// time. A subscription the opt-in reactivated returns to its previous state,
+// while one the opt-in created is removed rather than moved to another state.
+func ExpireSubscription(subscription Subscription, confirmation Confirmation, now time.Time) (*Subscription, error) {
+	if subscription.PK != confirmation.SubscriptionPK {
+		return nil, ErrConfirmationMismatch
+	}
+
+	if subscription.State != SubscriptionPending {
+		return nil, &TransitionError{From: subscription.State, To: SubscriptionPending}
+	}
+
+	if !confirmation.IsExpired(now) {
+		return nil, ErrConfirmationNotExpired
+	}
+
+	if confirmation.PreviousState != "" {
+		subscription.State = confirmation.PreviousState
+		subscription.StateChangedAt = now
+	}
+
+	subscription.Version++
+
+	return &subscription, nil
+}
+
+// CancelSubscription cancels a subscription to a list on the subscriber's
+// request.
+func CancelSubscription(subscription Subscription, now time.Time) (*Subscription, error) {
+	return transitionSubscription(subscription, SubscriptionUnsubscribed, now)
+}
+
+// BounceSubscription stops a subscription whose address bounced permanently.
+func BounceSubscription(subscription Subscription, now time.Time) (*Subscription, error) {
+	return transitionSubscription(subscription, SubscriptionBounced, now)
+}
+
+// ComplainSubscription stops a subscription whose subscriber reported a
+// message as spam.
+func ComplainSubscription(subscription Subscription, now time.Time) (*Subscription, error) {
+	return transitionSubscription(subscription, SubscriptionComplained, now)
+}
+
+// SuppressSubscription stops a subscription whose address has been suppressed.
+func SuppressSubscription(subscription Subscription, now time.Time) (*Subscription, error) {
+	return transitionSubscription(subscription, SubscriptionSuppressed, now)
+}
+
+// ForgetSubscription scrubs the personal data of a forgotten subscriber from a
+// subscription and moves it to the forgotten state.
+func ForgetSubscription(subscription Subscription, subscriber Subscriber, now time.Time) (*Subscription, error) {
+	if subscription.SubscriberPK != subscriber.PK {
+		return nil, ErrSubscriberMismatch
+	}
+
+	subscription.EmailAddress = subscriber.EmailAddress
+	subscription.Data = map[string]interface{}{}
+
+	return transitionSubscription(subscription, SubscriptionForgotten, now)
 }
 
-// CancelSubscription cancels a subscription to a list.
-func CancelSubscription(subscription Subscription) (*Subscription, error) {
-	if subscription.IsCancelled {
-		return nil, errors.New("invariant error")
+func transitionSubscription(subscription Subscription, to SubscriptionState, now time.Time) (*Subscription, error) {
+	if !subscription.State.CanTransition(to) {
+		return nil, &TransitionError{From: subscription.State, To: to}
 	}
 
-	subscription.IsCancelled = true
+	subscription.State = to
+	subscription.StateChangedAt = now
 	subscription.Version++
 
 	return &subscription, nil
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+)
+
+// SuppressionReason is the reason an email address or domain was suppressed.
+type SuppressionReason string
+
+// Suppression reasons.
+const (
+	SuppressionManual    SuppressionReason = "manual"
+	SuppressionBounce    SuppressionReason = "bounce"
+	SuppressionComplaint SuppressionReason = "complaint"
+	SuppressionLegal     SuppressionReason = "legal"
+)
+
+// Suppression blocks an email address or every address of a domain from being
+// subscribed.
+//
+// A suppression either belongs to an organization or, when OrganizationPK is
+// nil, applies globally to every organization.
+type Suppression struct {
+	PK             uuid.UUID
+	OrganizationPK uuid.UUID
+	// EmailAddress is the canonical form of the suppressed address. It is
+	// empty for domain suppressions.
+	EmailAddress EmailAddress
+	// Domain is the canonical form of the suppressed domain. It is empty for
+	// address suppressions.
+	Domain    string
+	Reason    SuppressionReason
+	CreatedAt time.Time
+	// ExpiresAt is the time the suppression lapses. A zero time never
+	// expires.
+	ExpiresAt time.Time
+}
+
+// Validate the suppression.
+func (s *Suppression) Validate() error {
+	return validationError(validation.ValidateStruct(s,
+		validation.Field(&s.PK, validation.Required),
+		validation.Field(&s.EmailAddress, validation.Skip.When(s.Domain != ""), validation.Required),
+		validation.Field(&s.Domain, validation.When(s.EmailAddress == "", validation.Required).Else(validation.Empty)),
+		validation.Field(&s.Reason, validation.Required, validation.In(
+			SuppressionManual,
+			SuppressionBounce,
+			SuppressionComplaint,
+			SuppressionLegal,
+		)),
+		validation.Field(&s.CreatedAt, validation.Required),
+		validation.Field(&s.ExpiresAt, validation.When(!s.ExpiresAt.IsZero(), validation.Min(s.CreatedAt))),
+	))
+}
+
+// IsGlobal reports whether the suppression applies to every organization.
+func (s *Suppression) IsGlobal() bool {
+	return s.OrganizationPK == uuid.Nil
+}
+
+// IsExpired reports whether the suppression has lapsed at the given time.
+func (s *Suppression) IsExpired(now time.Time) bool {
+	return !s.ExpiresAt.IsZero() && !now.Before(s.ExpiresAt)
+}
+
+// Matches reports whether the suppression blocks an email address.
+func (s *Suppression) Matches(addr EmailAddress) bool {
+	if s.Domain != "" {
+		return addr.Canonical().Domain() == s.Domain
+	}
+
+	return addr.Canonical() == s.EmailAddress
+}
+
+// SuppressedError is returned when subscribing a suppressed email address.
+type SuppressedError struct {
+	EmailAddress EmailAddress
+	Suppression  Suppression
+}
+
+func (e *SuppressedError) Error() string {
+	scope := "organization"
+	if e.Suppression.IsGlobal() {
+		scope = "global"
+	}
+
+	return fmt.Sprintf("invariant error: %s is suppressed by %s suppression (%s)", e.EmailAddress, scope, e.Suppression.Reason)
+}
+
+// Is reports whether the target is ErrInvariant.
+func (e *SuppressedError) Is(target error) bool {
+	return target == ErrInvariant
+}
+
+// CreateSuppression suppresses an email address or, when target has no @, a
+// domain. A nil organization primary key creates a global suppression.
+func CreateSuppression(orgPK uuid.UUID, target string, reason SuppressionReason, expiresAt time.Time) (*Suppression, error) {
+	s := &Suppression{
+		PK:             uuid.Must(uuid.NewV4()),
+		OrganizationPK: orgPK,
+		Reason:         reason,
+		CreatedAt:      time.Now(),
+		ExpiresAt:      expiresAt,
+	}
+
+	target = strings.TrimSpace(target)
+
+	if strings.Contains(target, "@") {
+		addr, err := ParseEmailAddress(target)
+		if err != nil {
+			return nil, err
+		}
+
+		s.EmailAddress = addr.Canonical()
+	} else {
+		domain, err := normalizeDomain(target)
+		if err != nil {
+			return nil, err
+		}
+
+		s.Domain = canonicalDomain(domain)
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// CheckSuppressions returns a SuppressedError when any of the suppressions
+// in effect at the given time blocks an email address.
+func CheckSuppressions(addr EmailAddress, suppressions []*Suppression, now time.Time) error {
+	for _, s := range suppressions {
+		if !s.IsExpired(now) && s.Matches(addr) {
+			return &SuppressedError{EmailAddress: addr, Suppression: *s}
+		}
+	}
+
+	return nil
+}
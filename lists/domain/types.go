@@ -1,19 +1,37 @@
 package domain
 
-import (
-	"strings"
+type SubscriptionData map[string]interface{}
+
+// DataMergeRule decides how conflicting fields are resolved when new
+// subscription data is merged into existing data.
+type DataMergeRule int
 
-	validation "github.com/go-ozzo/ozzo-validation/v4"
+const (
+	// PreferNewData overwrites existing fields with new values.
+	PreferNewData DataMergeRule = iota
+	// PreferExistingData keeps existing fields and only adds missing ones.
+	PreferExistingData
+	// ReplaceData discards existing data in favour of the new data.
+	ReplaceData
 )
 
-type EmailAddress string
+// MergeSubscriptionData merges new subscription data into existing data.
+func MergeSubscriptionData(existing, data map[string]interface{}, rule DataMergeRule) map[string]interface{} {
+	res := map[string]interface{}{}
 
-func NewEmailAddress(addr string) EmailAddress {
-	return EmailAddress(strings.TrimSpace(addr))
-}
+	if rule != ReplaceData {
+		for k, v := range existing {
+			res[k] = v
+		}
+	}
 
-func (a EmailAddress) Validate() error {
-	return validation.Validate(a, validation.Required)
-}
+	for k, v := range data {
+		if _, ok := res[k]; ok && rule == PreferExistingData {
+			continue
+		}
 
-type SubscriptionData map[string]interface{}
+		res[k] = v
+	}
+
+	return res
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "fmt"
+
+// AnyVersion is the version expected by commands that change an entity at
+// whichever version it is.
+const AnyVersion uint32 = 0
+
+// VersionConflictError is returned when an entity is not at the version a
+// command expects it to be. It matches ErrVersionConflict.
+type VersionConflictError struct {
+	Expected uint32
+	Actual   uint32
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("version conflict: expected version %d, found %d", e.Expected, e.Actual)
+}
+
+// Is reports whether the target is ErrVersionConflict.
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
+
+// CheckVersion returns a VersionConflictError when an entity at the given
+// version is not at the expected one, unless any version is expected.
+func CheckVersion(version, expected uint32) error {
+	if expected != AnyVersion && version != expected {
+		return &VersionConflictError{Expected: expected, Actual: version}
+	}
+
+	return nil
+}
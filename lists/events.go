@@ -0,0 +1,233 @@
+package lists
+
+import (
+	"encoding/json"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/janartodesk/domain-design/pkg/outbox"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Aggregate types of the events raised by the subscriber list commands.
+const (
+	AggregateOrganization = "organization"
+	AggregateList         = "list"
+	AggregateSubscriber   = "subscriber"
+	AggregateSubscription = "subscription"
+	AggregateSuppression  = "suppression"
+	AggregateExport       = "export"
+)
+
+func organizationEvent(org *domain.Organization) outbox.Metadata {
+	return outbox.Metadata{
+		AggregateType:    AggregateOrganization,
+		AggregateID:      org.PK,
+		AggregateVersion: org.Version,
+		OrganizationPK:   org.PK,
+	}
+}
+
+func listEvent(list *domain.List) outbox.Metadata {
+	return outbox.Metadata{
+		AggregateType:    AggregateList,
+		AggregateID:      list.PK,
+		AggregateVersion: list.Version,
+		OrganizationPK:   list.OrganizationPK,
+	}
+}
+
+func subscriberEvent(subscriber *domain.Subscriber) outbox.Metadata {
+	return outbox.Metadata{
+		AggregateType:    AggregateSubscriber,
+		AggregateID:      subscriber.PK,
+		AggregateVersion: subscriber.Version,
+		OrganizationPK:   subscriber.OrganizationPK,
+	}
+}
+
+func subscriptionEvent(subscription *domain.Subscription) outbox.Metadata {
+	return outbox.Metadata{
+		AggregateType:    AggregateSubscription,
+		AggregateID:      subscription.PK,
+		AggregateVersion: subscription.Version,
+		OrganizationPK:   subscription.OrganizationPK,
+	}
+}
+
+// suppressionEvent returns the metadata of a suppression event. Suppressions
+// are never changed, so they are not versioned.
+func suppressionEvent(suppression *domain.Suppression) outbox.Metadata {
+	return outbox.Metadata{
+		AggregateType:  AggregateSuppression,
+		AggregateID:    suppression.PK,
+		OrganizationPK: suppression.OrganizationPK,
+	}
+}
+
+// exportEvent returns the metadata of an export of a list. Exports only
+// record that the list was read, so each one is an unversioned aggregate of
+// its own and leaves the version history of the list untouched.
+func exportEvent(list *domain.List) outbox.Metadata {
+	return outbox.Metadata{
+		AggregateType:  AggregateExport,
+		AggregateID:    uuid.Must(uuid.NewV4()),
+		OrganizationPK: list.OrganizationPK,
+	}
+}
+
+func newSubscriberCreated(subscriber *domain.Subscriber) *SubscriberCreated {
+	return &SubscriberCreated{
+		SubscriberPK:   subscriber.PK.Bytes(),
+		OrganizationPK: subscriber.OrganizationPK.Bytes(),
+		EmailAddress:   string(subscriber.EmailAddress),
+	}
+}
+
+// newSubscriberSubscribed returns the event of a subscription that was created
+// or reactivated, either active or pending. The previous subscription is nil
+// for new subscriptions.
+func newSubscriberSubscribed(subscription, previous *domain.Subscription) (*SubscriberSubscribed, error) {
+	data, err := dataStruct(subscription.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &SubscriberSubscribed{
+		SubscriptionPK: subscription.PK.Bytes(),
+		SubscriberPK:   subscription.SubscriberPK.Bytes(),
+		ListPK:         subscription.ListPK.Bytes(),
+		OrganizationPK: subscription.OrganizationPK.Bytes(),
+		EmailAddress:   string(subscription.EmailAddress),
+		Data:           data,
+		SchemaVersion:  subscription.SchemaVersion,
+		State:          string(subscription.State),
+	}
+
+	if previous != nil {
+		event.PreviousState = string(previous.State)
+	}
+
+	return event, nil
+}
+
+func newSubscriberResubscribed(subscription *domain.Subscription) (*SubscriberResubscribed, error) {
+	data, err := dataStruct(subscription.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubscriberResubscribed{
+		SubscriptionPK: subscription.PK.Bytes(),
+		SubscriberPK:   subscription.SubscriberPK.Bytes(),
+		ListPK:         subscription.ListPK.Bytes(),
+		OrganizationPK: subscription.OrganizationPK.Bytes(),
+		Data:           data,
+		SchemaVersion:  subscription.SchemaVersion,
+	}, nil
+}
+
+func newSubscriptionDataChanged(subscription *domain.Subscription) (*SubscriptionDataChanged, error) {
+	data, err := dataStruct(subscription.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubscriptionDataChanged{
+		SubscriptionPK: subscription.PK.Bytes(),
+		SubscriberPK:   subscription.SubscriberPK.Bytes(),
+		ListPK:         subscription.ListPK.Bytes(),
+		OrganizationPK: subscription.OrganizationPK.Bytes(),
+		Data:           data,
+		SchemaVersion:  subscription.SchemaVersion,
+	}, nil
+}
+
+// redactSubscriber returns a function erasing the personal data of a forgotten
+// subscriber from the events of the subscriber and their subscriptions. The
+// email address is replaced with the subscriber's tombstone and the
+// subscription data is cleared.
+func redactSubscriber(subscriber *domain.Subscriber) outbox.RedactFunc {
+	return func(event proto.Message) bool {
+		switch e := event.(type) {
+		case *SubscriberCreated:
+			e.EmailAddress = string(subscriber.EmailAddress)
+		case *SubscriberSubscribed:
+			e.EmailAddress = string(subscriber.EmailAddress)
+			e.Data = &structpb.Struct{}
+		case *SubscriberResubscribed:
+			e.Data = &structpb.Struct{}
+		case *SubscriptionDataChanged:
+			e.Data = &structpb.Struct{}
+		default:
+			return false
+		}
+
+		return true
+	}
+}
+
+// dataStruct converts subscription data to its JSON representation as a
+// protobuf struct.
+func dataStruct(data map[string]interface{}) (*structpb.Struct, error) {
+	if data == nil {
+		return &structpb.Struct{}, nil
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &structpb.Struct{}
+
+	if err := protojson.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// fieldsList converts schema fields to their JSON representation as a
+// protobuf list.
+func fieldsList(fields []domain.Field) (*structpb.ListValue, error) {
+	if fields == nil {
+		return &structpb.ListValue{}, nil
+	}
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &structpb.ListValue{}
+
+	if err := protojson.Unmarshal(b, l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// structData converts a protobuf struct back to subscription data.
+func structData(s *structpb.Struct) map[string]interface{} {
+	return s.AsMap()
+}
+
+// listFields converts a protobuf list back to schema fields.
+func listFields(l *structpb.ListValue) ([]domain.Field, error) {
+	b, err := protojson.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []domain.Field{}
+
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
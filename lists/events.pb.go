@@ -9,6 +9,8 @@ package lists
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -25,7 +27,8 @@ type ListDeleted struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ListPK []byte `protobuf:"bytes,1,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	ListPK         []byte `protobuf:"bytes,1,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,2,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
 }
 
 func (x *ListDeleted) Reset() {
@@ -67,6 +70,13 @@ func (x *ListDeleted) GetListPK() []byte {
 	return nil
 }
 
+func (x *ListDeleted) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
 type SubscriberForgotten struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -127,8 +137,9 @@ type SubscriberOptedIn struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SubscriberPK []byte `protobuf:"bytes,1,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
-	ListPK       []byte `protobuf:"bytes,2,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	SubscriberPK   []byte `protobuf:"bytes,1,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
+	ListPK         []byte `protobuf:"bytes,2,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,3,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
 }
 
 func (x *SubscriberOptedIn) Reset() {
@@ -177,13 +188,21 @@ func (x *SubscriberOptedIn) GetListPK() []byte {
 	return nil
 }
 
+func (x *SubscriberOptedIn) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
 type SubscriberOptedOut struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SubscriberPK []byte `protobuf:"bytes,1,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
-	ListPK       []byte `protobuf:"bytes,2,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	SubscriberPK   []byte `protobuf:"bytes,1,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
+	ListPK         []byte `protobuf:"bytes,2,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,3,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
 }
 
 func (x *SubscriberOptedOut) Reset() {
@@ -232,107 +251,2116 @@ func (x *SubscriberOptedOut) GetListPK() []byte {
 	return nil
 }
 
-var File_lists_events_proto protoreflect.FileDescriptor
+func (x *SubscriberOptedOut) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
 
-var file_lists_events_proto_rawDesc = []byte{
-	0x0a, 0x12, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x73, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x22, 0x25, 0x0a, 0x0b,
-	0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x4c,
-	0x69, 0x73, 0x74, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x4c, 0x69, 0x73,
-	0x74, 0x50, 0x4b, 0x22, 0x61, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
-	0x72, 0x46, 0x6f, 0x72, 0x67, 0x6f, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x75,
-	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x12, 0x26,
-	0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x22, 0x4f, 0x0a, 0x11, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
-	0x69, 0x62, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x65, 0x64, 0x49, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x53,
-	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x12,
-	0x16, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x22, 0x50, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x63,
-	0x72, 0x69, 0x62, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x12, 0x22, 0x0a,
-	0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50,
-	0x4b, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x61, 0x6e, 0x61, 0x72, 0x74, 0x6f, 0x64,
-	0x65, 0x73, 0x6b, 0x2f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2d, 0x64, 0x65, 0x73, 0x69, 0x67,
-	0x6e, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+type SubscriptionExpired struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubscriptionPK []byte `protobuf:"bytes,1,opt,name=SubscriptionPK,proto3" json:"SubscriptionPK,omitempty"`
+	SubscriberPK   []byte `protobuf:"bytes,2,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
+	ListPK         []byte `protobuf:"bytes,3,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,4,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	State          string `protobuf:"bytes,5,opt,name=State,proto3" json:"State,omitempty"`
 }
 
-var (
-	file_lists_events_proto_rawDescOnce sync.Once
-	file_lists_events_proto_rawDescData = file_lists_events_proto_rawDesc
-)
+func (x *SubscriptionExpired) Reset() {
+	*x = SubscriptionExpired{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_lists_events_proto_rawDescGZIP() []byte {
-	file_lists_events_proto_rawDescOnce.Do(func() {
-		file_lists_events_proto_rawDescData = protoimpl.X.CompressGZIP(file_lists_events_proto_rawDescData)
-	})
-	return file_lists_events_proto_rawDescData
+func (x *SubscriptionExpired) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_lists_events_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
-var file_lists_events_proto_goTypes = []interface{}{
-	(*ListDeleted)(nil),         // 0: domain.events.lists.v1.ListDeleted
-	(*SubscriberForgotten)(nil), // 1: domain.events.lists.v1.SubscriberForgotten
-	(*SubscriberOptedIn)(nil),   // 2: domain.events.lists.v1.SubscriberOptedIn
-	(*SubscriberOptedOut)(nil),  // 3: domain.events.lists.v1.SubscriberOptedOut
+func (*SubscriptionExpired) ProtoMessage() {}
+
+func (x *SubscriptionExpired) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_lists_events_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+// Deprecated: Use SubscriptionExpired.ProtoReflect.Descriptor instead.
+func (*SubscriptionExpired) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{4}
 }
 
-func init() { file_lists_events_proto_init() }
-func file_lists_events_proto_init() {
-	if File_lists_events_proto != nil {
-		return
+func (x *SubscriptionExpired) GetSubscriptionPK() []byte {
+	if x != nil {
+		return x.SubscriptionPK
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_lists_events_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListDeleted); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_lists_events_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubscriberForgotten); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return nil
+}
+
+func (x *SubscriptionExpired) GetSubscriberPK() []byte {
+	if x != nil {
+		return x.SubscriberPK
+	}
+	return nil
+}
+
+func (x *SubscriptionExpired) GetListPK() []byte {
+	if x != nil {
+		return x.ListPK
+	}
+	return nil
+}
+
+func (x *SubscriptionExpired) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *SubscriptionExpired) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type SubscriberResubscribed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubscriptionPK []byte           `protobuf:"bytes,1,opt,name=SubscriptionPK,proto3" json:"SubscriptionPK,omitempty"`
+	SubscriberPK   []byte           `protobuf:"bytes,2,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
+	ListPK         []byte           `protobuf:"bytes,3,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte           `protobuf:"bytes,4,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	Data           *structpb.Struct `protobuf:"bytes,5,opt,name=Data,proto3" json:"Data,omitempty"`
+	SchemaVersion  uint32           `protobuf:"varint,6,opt,name=SchemaVersion,proto3" json:"SchemaVersion,omitempty"`
+}
+
+func (x *SubscriberResubscribed) Reset() {
+	*x = SubscriberResubscribed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriberResubscribed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriberResubscribed) ProtoMessage() {}
+
+func (x *SubscriberResubscribed) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_lists_events_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubscriberOptedIn); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriberResubscribed.ProtoReflect.Descriptor instead.
+func (*SubscriberResubscribed) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SubscriberResubscribed) GetSubscriptionPK() []byte {
+	if x != nil {
+		return x.SubscriptionPK
+	}
+	return nil
+}
+
+func (x *SubscriberResubscribed) GetSubscriberPK() []byte {
+	if x != nil {
+		return x.SubscriberPK
+	}
+	return nil
+}
+
+func (x *SubscriberResubscribed) GetListPK() []byte {
+	if x != nil {
+		return x.ListPK
+	}
+	return nil
+}
+
+func (x *SubscriberResubscribed) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *SubscriberResubscribed) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SubscriberResubscribed) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+type ListExported struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ListPK         []byte   `protobuf:"bytes,1,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte   `protobuf:"bytes,2,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	Format         string   `protobuf:"bytes,3,opt,name=Format,proto3" json:"Format,omitempty"`
+	Columns        []string `protobuf:"bytes,4,rep,name=Columns,proto3" json:"Columns,omitempty"`
+	States         []string `protobuf:"bytes,5,rep,name=States,proto3" json:"States,omitempty"`
+	Count          uint64   `protobuf:"varint,6,opt,name=Count,proto3" json:"Count,omitempty"`
+}
+
+func (x *ListExported) Reset() {
+	*x = ListExported{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExported) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExported) ProtoMessage() {}
+
+func (x *ListExported) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_lists_events_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubscriberOptedOut); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExported.ProtoReflect.Descriptor instead.
+func (*ListExported) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListExported) GetListPK() []byte {
+	if x != nil {
+		return x.ListPK
+	}
+	return nil
+}
+
+func (x *ListExported) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *ListExported) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ListExported) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *ListExported) GetStates() []string {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *ListExported) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type SuppressionAdded struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SuppressionPK  []byte                 `protobuf:"bytes,1,opt,name=SuppressionPK,proto3" json:"SuppressionPK,omitempty"`
+	OrganizationPK []byte                 `protobuf:"bytes,2,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	EmailAddress   string                 `protobuf:"bytes,3,opt,name=EmailAddress,proto3" json:"EmailAddress,omitempty"`
+	Domain         string                 `protobuf:"bytes,4,opt,name=Domain,proto3" json:"Domain,omitempty"`
+	Reason         string                 `protobuf:"bytes,5,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"`
+}
+
+func (x *SuppressionAdded) Reset() {
+	*x = SuppressionAdded{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuppressionAdded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuppressionAdded) ProtoMessage() {}
+
+func (x *SuppressionAdded) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuppressionAdded.ProtoReflect.Descriptor instead.
+func (*SuppressionAdded) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SuppressionAdded) GetSuppressionPK() []byte {
+	if x != nil {
+		return x.SuppressionPK
+	}
+	return nil
+}
+
+func (x *SuppressionAdded) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *SuppressionAdded) GetEmailAddress() string {
+	if x != nil {
+		return x.EmailAddress
+	}
+	return ""
+}
+
+func (x *SuppressionAdded) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *SuppressionAdded) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SuppressionAdded) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type SuppressionRemoved struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SuppressionPK  []byte `protobuf:"bytes,1,opt,name=SuppressionPK,proto3" json:"SuppressionPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,2,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+}
+
+func (x *SuppressionRemoved) Reset() {
+	*x = SuppressionRemoved{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuppressionRemoved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuppressionRemoved) ProtoMessage() {}
+
+func (x *SuppressionRemoved) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuppressionRemoved.ProtoReflect.Descriptor instead.
+func (*SuppressionRemoved) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SuppressionRemoved) GetSuppressionPK() []byte {
+	if x != nil {
+		return x.SuppressionPK
+	}
+	return nil
+}
+
+func (x *SuppressionRemoved) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+type SubscriptionSuppressed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubscriptionPK []byte `protobuf:"bytes,1,opt,name=SubscriptionPK,proto3" json:"SubscriptionPK,omitempty"`
+	SubscriberPK   []byte `protobuf:"bytes,2,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
+	ListPK         []byte `protobuf:"bytes,3,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,4,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	SuppressionPK  []byte `protobuf:"bytes,5,opt,name=SuppressionPK,proto3" json:"SuppressionPK,omitempty"`
+}
+
+func (x *SubscriptionSuppressed) Reset() {
+	*x = SubscriptionSuppressed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionSuppressed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionSuppressed) ProtoMessage() {}
+
+func (x *SubscriptionSuppressed) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionSuppressed.ProtoReflect.Descriptor instead.
+func (*SubscriptionSuppressed) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubscriptionSuppressed) GetSubscriptionPK() []byte {
+	if x != nil {
+		return x.SubscriptionPK
+	}
+	return nil
+}
+
+func (x *SubscriptionSuppressed) GetSubscriberPK() []byte {
+	if x != nil {
+		return x.SubscriberPK
+	}
+	return nil
+}
+
+func (x *SubscriptionSuppressed) GetListPK() []byte {
+	if x != nil {
+		return x.ListPK
+	}
+	return nil
+}
+
+func (x *SubscriptionSuppressed) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *SubscriptionSuppressed) GetSuppressionPK() []byte {
+	if x != nil {
+		return x.SuppressionPK
+	}
+	return nil
+}
+
+type SubscriptionBounced struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubscriptionPK []byte `protobuf:"bytes,1,opt,name=SubscriptionPK,proto3" json:"SubscriptionPK,omitempty"`
+	SubscriberPK   []byte `protobuf:"bytes,2,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
+	ListPK         []byte `protobuf:"bytes,3,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,4,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	Status         string `protobuf:"bytes,5,opt,name=Status,proto3" json:"Status,omitempty"`
+}
+
+func (x *SubscriptionBounced) Reset() {
+	*x = SubscriptionBounced{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionBounced) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionBounced) ProtoMessage() {}
+
+func (x *SubscriptionBounced) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionBounced.ProtoReflect.Descriptor instead.
+func (*SubscriptionBounced) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SubscriptionBounced) GetSubscriptionPK() []byte {
+	if x != nil {
+		return x.SubscriptionPK
+	}
+	return nil
+}
+
+func (x *SubscriptionBounced) GetSubscriberPK() []byte {
+	if x != nil {
+		return x.SubscriberPK
+	}
+	return nil
+}
+
+func (x *SubscriptionBounced) GetListPK() []byte {
+	if x != nil {
+		return x.ListPK
+	}
+	return nil
+}
+
+func (x *SubscriptionBounced) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *SubscriptionBounced) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type SubscriptionComplained struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubscriptionPK []byte `protobuf:"bytes,1,opt,name=SubscriptionPK,proto3" json:"SubscriptionPK,omitempty"`
+	SubscriberPK   []byte `protobuf:"bytes,2,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
+	ListPK         []byte `protobuf:"bytes,3,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,4,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	FeedbackType   string `protobuf:"bytes,5,opt,name=FeedbackType,proto3" json:"FeedbackType,omitempty"`
+}
+
+func (x *SubscriptionComplained) Reset() {
+	*x = SubscriptionComplained{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionComplained) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionComplained) ProtoMessage() {}
+
+func (x *SubscriptionComplained) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionComplained.ProtoReflect.Descriptor instead.
+func (*SubscriptionComplained) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SubscriptionComplained) GetSubscriptionPK() []byte {
+	if x != nil {
+		return x.SubscriptionPK
+	}
+	return nil
+}
+
+func (x *SubscriptionComplained) GetSubscriberPK() []byte {
+	if x != nil {
+		return x.SubscriberPK
+	}
+	return nil
+}
+
+func (x *SubscriptionComplained) GetListPK() []byte {
+	if x != nil {
+		return x.ListPK
+	}
+	return nil
+}
+
+func (x *SubscriptionComplained) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *SubscriptionComplained) GetFeedbackType() string {
+	if x != nil {
+		return x.FeedbackType
+	}
+	return ""
+}
+
+type SoftBounceRecorded struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubscriberPK   []byte `protobuf:"bytes,1,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,2,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	Count          uint32 `protobuf:"varint,3,opt,name=Count,proto3" json:"Count,omitempty"`
+	Status         string `protobuf:"bytes,4,opt,name=Status,proto3" json:"Status,omitempty"`
+}
+
+func (x *SoftBounceRecorded) Reset() {
+	*x = SoftBounceRecorded{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SoftBounceRecorded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SoftBounceRecorded) ProtoMessage() {}
+
+func (x *SoftBounceRecorded) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SoftBounceRecorded.ProtoReflect.Descriptor instead.
+func (*SoftBounceRecorded) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SoftBounceRecorded) GetSubscriberPK() []byte {
+	if x != nil {
+		return x.SubscriberPK
+	}
+	return nil
+}
+
+func (x *SoftBounceRecorded) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *SoftBounceRecorded) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SoftBounceRecorded) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type OrganizationCreated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrganizationPK []byte `protobuf:"bytes,1,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	Name           string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+}
+
+func (x *OrganizationCreated) Reset() {
+	*x = OrganizationCreated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrganizationCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationCreated) ProtoMessage() {}
+
+func (x *OrganizationCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationCreated.ProtoReflect.Descriptor instead.
+func (*OrganizationCreated) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *OrganizationCreated) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *OrganizationCreated) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type OrganizationRenamed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrganizationPK []byte `protobuf:"bytes,1,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	Name           string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	PreviousName   string `protobuf:"bytes,3,opt,name=PreviousName,proto3" json:"PreviousName,omitempty"`
+}
+
+func (x *OrganizationRenamed) Reset() {
+	*x = OrganizationRenamed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrganizationRenamed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationRenamed) ProtoMessage() {}
+
+func (x *OrganizationRenamed) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationRenamed.ProtoReflect.Descriptor instead.
+func (*OrganizationRenamed) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OrganizationRenamed) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *OrganizationRenamed) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrganizationRenamed) GetPreviousName() string {
+	if x != nil {
+		return x.PreviousName
+	}
+	return ""
+}
+
+type ListCreated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ListPK         []byte `protobuf:"bytes,1,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,2,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	Title          string `protobuf:"bytes,3,opt,name=Title,proto3" json:"Title,omitempty"`
+}
+
+func (x *ListCreated) Reset() {
+	*x = ListCreated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCreated) ProtoMessage() {}
+
+func (x *ListCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCreated.ProtoReflect.Descriptor instead.
+func (*ListCreated) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListCreated) GetListPK() []byte {
+	if x != nil {
+		return x.ListPK
+	}
+	return nil
+}
+
+func (x *ListCreated) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *ListCreated) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type ListRenamed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ListPK         []byte `protobuf:"bytes,1,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,2,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	Title          string `protobuf:"bytes,3,opt,name=Title,proto3" json:"Title,omitempty"`
+	PreviousTitle  string `protobuf:"bytes,4,opt,name=PreviousTitle,proto3" json:"PreviousTitle,omitempty"`
+}
+
+func (x *ListRenamed) Reset() {
+	*x = ListRenamed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRenamed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRenamed) ProtoMessage() {}
+
+func (x *ListRenamed) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRenamed.ProtoReflect.Descriptor instead.
+func (*ListRenamed) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListRenamed) GetListPK() []byte {
+	if x != nil {
+		return x.ListPK
+	}
+	return nil
+}
+
+func (x *ListRenamed) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *ListRenamed) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ListRenamed) GetPreviousTitle() string {
+	if x != nil {
+		return x.PreviousTitle
+	}
+	return ""
+}
+
+type ListSchemaChanged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ListPK         []byte              `protobuf:"bytes,1,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte              `protobuf:"bytes,2,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	SchemaVersion  uint32              `protobuf:"varint,3,opt,name=SchemaVersion,proto3" json:"SchemaVersion,omitempty"`
+	Fields         *structpb.ListValue `protobuf:"bytes,4,opt,name=Fields,proto3" json:"Fields,omitempty"`
+}
+
+func (x *ListSchemaChanged) Reset() {
+	*x = ListSchemaChanged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSchemaChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchemaChanged) ProtoMessage() {}
+
+func (x *ListSchemaChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchemaChanged.ProtoReflect.Descriptor instead.
+func (*ListSchemaChanged) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListSchemaChanged) GetListPK() []byte {
+	if x != nil {
+		return x.ListPK
+	}
+	return nil
+}
+
+func (x *ListSchemaChanged) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *ListSchemaChanged) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *ListSchemaChanged) GetFields() *structpb.ListValue {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type SubscriberCreated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubscriberPK   []byte `protobuf:"bytes,1,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,2,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	EmailAddress   string `protobuf:"bytes,3,opt,name=EmailAddress,proto3" json:"EmailAddress,omitempty"`
+}
+
+func (x *SubscriberCreated) Reset() {
+	*x = SubscriberCreated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriberCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriberCreated) ProtoMessage() {}
+
+func (x *SubscriberCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriberCreated.ProtoReflect.Descriptor instead.
+func (*SubscriberCreated) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SubscriberCreated) GetSubscriberPK() []byte {
+	if x != nil {
+		return x.SubscriberPK
+	}
+	return nil
+}
+
+func (x *SubscriberCreated) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *SubscriberCreated) GetEmailAddress() string {
+	if x != nil {
+		return x.EmailAddress
+	}
+	return ""
+}
+
+type SubscriberSubscribed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubscriptionPK []byte           `protobuf:"bytes,1,opt,name=SubscriptionPK,proto3" json:"SubscriptionPK,omitempty"`
+	SubscriberPK   []byte           `protobuf:"bytes,2,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
+	ListPK         []byte           `protobuf:"bytes,3,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte           `protobuf:"bytes,4,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	EmailAddress   string           `protobuf:"bytes,5,opt,name=EmailAddress,proto3" json:"EmailAddress,omitempty"`
+	Data           *structpb.Struct `protobuf:"bytes,6,opt,name=Data,proto3" json:"Data,omitempty"`
+	SchemaVersion  uint32           `protobuf:"varint,7,opt,name=SchemaVersion,proto3" json:"SchemaVersion,omitempty"`
+	State          string           `protobuf:"bytes,8,opt,name=State,proto3" json:"State,omitempty"`
+	PreviousState  string           `protobuf:"bytes,9,opt,name=PreviousState,proto3" json:"PreviousState,omitempty"`
+}
+
+func (x *SubscriberSubscribed) Reset() {
+	*x = SubscriberSubscribed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriberSubscribed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriberSubscribed) ProtoMessage() {}
+
+func (x *SubscriberSubscribed) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriberSubscribed.ProtoReflect.Descriptor instead.
+func (*SubscriberSubscribed) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SubscriberSubscribed) GetSubscriptionPK() []byte {
+	if x != nil {
+		return x.SubscriptionPK
+	}
+	return nil
+}
+
+func (x *SubscriberSubscribed) GetSubscriberPK() []byte {
+	if x != nil {
+		return x.SubscriberPK
+	}
+	return nil
+}
+
+func (x *SubscriberSubscribed) GetListPK() []byte {
+	if x != nil {
+		return x.ListPK
+	}
+	return nil
+}
+
+func (x *SubscriberSubscribed) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *SubscriberSubscribed) GetEmailAddress() string {
+	if x != nil {
+		return x.EmailAddress
+	}
+	return ""
+}
+
+func (x *SubscriberSubscribed) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SubscriberSubscribed) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *SubscriberSubscribed) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *SubscriberSubscribed) GetPreviousState() string {
+	if x != nil {
+		return x.PreviousState
+	}
+	return ""
+}
+
+type SubscriberUnsubscribed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubscriptionPK []byte `protobuf:"bytes,1,opt,name=SubscriptionPK,proto3" json:"SubscriptionPK,omitempty"`
+	SubscriberPK   []byte `protobuf:"bytes,2,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
+	ListPK         []byte `protobuf:"bytes,3,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,4,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	PreviousState  string `protobuf:"bytes,5,opt,name=PreviousState,proto3" json:"PreviousState,omitempty"`
+}
+
+func (x *SubscriberUnsubscribed) Reset() {
+	*x = SubscriberUnsubscribed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriberUnsubscribed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriberUnsubscribed) ProtoMessage() {}
+
+func (x *SubscriberUnsubscribed) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriberUnsubscribed.ProtoReflect.Descriptor instead.
+func (*SubscriberUnsubscribed) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SubscriberUnsubscribed) GetSubscriptionPK() []byte {
+	if x != nil {
+		return x.SubscriptionPK
+	}
+	return nil
+}
+
+func (x *SubscriberUnsubscribed) GetSubscriberPK() []byte {
+	if x != nil {
+		return x.SubscriberPK
+	}
+	return nil
+}
+
+func (x *SubscriberUnsubscribed) GetListPK() []byte {
+	if x != nil {
+		return x.ListPK
+	}
+	return nil
+}
+
+func (x *SubscriberUnsubscribed) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *SubscriberUnsubscribed) GetPreviousState() string {
+	if x != nil {
+		return x.PreviousState
+	}
+	return ""
+}
+
+type SubscriptionDataChanged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubscriptionPK []byte           `protobuf:"bytes,1,opt,name=SubscriptionPK,proto3" json:"SubscriptionPK,omitempty"`
+	SubscriberPK   []byte           `protobuf:"bytes,2,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
+	ListPK         []byte           `protobuf:"bytes,3,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte           `protobuf:"bytes,4,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	Data           *structpb.Struct `protobuf:"bytes,5,opt,name=Data,proto3" json:"Data,omitempty"`
+	SchemaVersion  uint32           `protobuf:"varint,6,opt,name=SchemaVersion,proto3" json:"SchemaVersion,omitempty"`
+}
+
+func (x *SubscriptionDataChanged) Reset() {
+	*x = SubscriptionDataChanged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionDataChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionDataChanged) ProtoMessage() {}
+
+func (x *SubscriptionDataChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionDataChanged.ProtoReflect.Descriptor instead.
+func (*SubscriptionDataChanged) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SubscriptionDataChanged) GetSubscriptionPK() []byte {
+	if x != nil {
+		return x.SubscriptionPK
+	}
+	return nil
+}
+
+func (x *SubscriptionDataChanged) GetSubscriberPK() []byte {
+	if x != nil {
+		return x.SubscriberPK
+	}
+	return nil
+}
+
+func (x *SubscriptionDataChanged) GetListPK() []byte {
+	if x != nil {
+		return x.ListPK
+	}
+	return nil
+}
+
+func (x *SubscriptionDataChanged) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *SubscriptionDataChanged) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SubscriptionDataChanged) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+type SoftBouncesReset struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubscriberPK   []byte `protobuf:"bytes,1,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,2,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+}
+
+func (x *SoftBouncesReset) Reset() {
+	*x = SoftBouncesReset{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SoftBouncesReset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SoftBouncesReset) ProtoMessage() {}
+
+func (x *SoftBouncesReset) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SoftBouncesReset.ProtoReflect.Descriptor instead.
+func (*SoftBouncesReset) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SoftBouncesReset) GetSubscriberPK() []byte {
+	if x != nil {
+		return x.SubscriberPK
+	}
+	return nil
+}
+
+func (x *SoftBouncesReset) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+type SubscriptionForgotten struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubscriptionPK []byte `protobuf:"bytes,1,opt,name=SubscriptionPK,proto3" json:"SubscriptionPK,omitempty"`
+	SubscriberPK   []byte `protobuf:"bytes,2,opt,name=SubscriberPK,proto3" json:"SubscriberPK,omitempty"`
+	ListPK         []byte `protobuf:"bytes,3,opt,name=ListPK,proto3" json:"ListPK,omitempty"`
+	OrganizationPK []byte `protobuf:"bytes,4,opt,name=OrganizationPK,proto3" json:"OrganizationPK,omitempty"`
+	PreviousState  string `protobuf:"bytes,5,opt,name=PreviousState,proto3" json:"PreviousState,omitempty"`
+}
+
+func (x *SubscriptionForgotten) Reset() {
+	*x = SubscriptionForgotten{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lists_events_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionForgotten) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionForgotten) ProtoMessage() {}
+
+func (x *SubscriptionForgotten) ProtoReflect() protoreflect.Message {
+	mi := &file_lists_events_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionForgotten.ProtoReflect.Descriptor instead.
+func (*SubscriptionForgotten) Descriptor() ([]byte, []int) {
+	return file_lists_events_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SubscriptionForgotten) GetSubscriptionPK() []byte {
+	if x != nil {
+		return x.SubscriptionPK
+	}
+	return nil
+}
+
+func (x *SubscriptionForgotten) GetSubscriberPK() []byte {
+	if x != nil {
+		return x.SubscriberPK
+	}
+	return nil
+}
+
+func (x *SubscriptionForgotten) GetListPK() []byte {
+	if x != nil {
+		return x.ListPK
+	}
+	return nil
+}
+
+func (x *SubscriptionForgotten) GetOrganizationPK() []byte {
+	if x != nil {
+		return x.OrganizationPK
+	}
+	return nil
+}
+
+func (x *SubscriptionForgotten) GetPreviousState() string {
+	if x != nil {
+		return x.PreviousState
+	}
+	return ""
+}
+
+var File_lists_events_proto protoreflect.FileDescriptor
+
+var file_lists_events_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4d, 0x0a, 0x0b, 0x4c,
+	0x69, 0x73, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x22, 0x61, 0x0a, 0x13, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x46, 0x6f, 0x72, 0x67, 0x6f, 0x74, 0x74, 0x65,
+	0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50,
+	0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x72, 0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f,
+	0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x22, 0x77, 0x0a,
+	0x11, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x65, 0x64,
+	0x49, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72,
+	0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x12, 0x26,
+	0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x22, 0x78, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x12, 0x22, 0x0a, 0x0c,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b,
+	0x12, 0x16, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b,
+	0x22, 0xb7, 0x01, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b,
+	0x12, 0x22, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x72, 0x50, 0x4b, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e,
+	0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0xf7, 0x01, 0x0a, 0x16, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x52, 0x65, 0x73, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x22, 0x0a,
+	0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50,
+	0x4b, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67,
+	0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50,
+	0x4b, 0x12, 0x2b, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x24,
+	0x0a, 0x0d, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x22, 0xae, 0x01, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x12, 0x26, 0x0a,
+	0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xee, 0x01, 0x0a, 0x10, 0x53, 0x75, 0x70, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x64, 0x64, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x75,
+	0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0d, 0x53, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x4b,
+	0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x22, 0x0a, 0x0c, 0x45, 0x6d, 0x61, 0x69,
+	0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x09,
+	0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x62, 0x0a, 0x12, 0x53, 0x75, 0x70, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0d,
+	0x53, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0d, 0x53, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x22, 0xca, 0x01, 0x0a, 0x16, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x70, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x22, 0x0a,
+	0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50,
+	0x4b, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67,
+	0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50,
+	0x4b, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x50, 0x4b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x53, 0x75, 0x70, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x22, 0xb9, 0x01, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x64, 0x12,
+	0x26, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50,
+	0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x12, 0x16, 0x0a, 0x06, 0x4c,
+	0x69, 0x73, 0x74, 0x50, 0x4b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67,
+	0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x16, 0x0a, 0x06, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x22, 0xc8, 0x01, 0x0a, 0x16, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x65, 0x64, 0x12, 0x26,
+	0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x4b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x22, 0x0a, 0x0c, 0x46, 0x65,
+	0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x46, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x22, 0x8e,
+	0x01, 0x0a, 0x12, 0x53, 0x6f, 0x66, 0x74, 0x42, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x72, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67,
+	0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50,
+	0x4b, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22,
+	0x51, 0x0a, 0x13, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e,
+	0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x12,
+	0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61,
+	0x6d, 0x65, 0x22, 0x75, 0x0a, 0x13, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67,
+	0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50,
+	0x4b, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75,
+	0x73, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x50, 0x72, 0x65,
+	0x76, 0x69, 0x6f, 0x75, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x63, 0x0a, 0x0b, 0x4c, 0x69, 0x73,
+	0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b,
+	0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x69, 0x74, 0x6c,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x22, 0x89,
+	0x01, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e,
+	0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x14,
+	0x0a, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54,
+	0x69, 0x74, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x50, 0x72, 0x65,
+	0x76, 0x69, 0x6f, 0x75, 0x73, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x22, 0xad, 0x01, 0x0a, 0x11, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b,
+	0x12, 0x24, 0x0a, 0x0d, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x06, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x06, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x11, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x22, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x72, 0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f, 0x72,
+	0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x22, 0x0a, 0x0c,
+	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x22, 0xd5, 0x02, 0x0a, 0x14, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50,
+	0x4b, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50,
+	0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x72, 0x50, 0x4b, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x12, 0x26, 0x0a,
+	0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x22, 0x0a, 0x0c, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x45, 0x6d, 0x61,
+	0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2b, 0x0a, 0x04, 0x44, 0x61, 0x74,
+	0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x50, 0x72, 0x65, 0x76, 0x69,
+	0x6f, 0x75, 0x73, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0xca, 0x01, 0x0a, 0x16, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x55, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x22, 0x0a, 0x0c, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x12,
+	0x16, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12,
+	0x24, 0x0a, 0x0d, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0xf8, 0x01, 0x0a, 0x17, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x12, 0x16, 0x0a,
+	0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x4c,
+	0x69, 0x73, 0x74, 0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f,
+	0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x2b, 0x0a,
+	0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0d, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x5e, 0x0a, 0x10, 0x53, 0x6f, 0x66, 0x74, 0x42, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x65, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x72, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x12, 0x26, 0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b,
+	0x22, 0xc9, 0x01, 0x0a, 0x15, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x46, 0x6f, 0x72, 0x67, 0x6f, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x50, 0x4b, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72,
+	0x50, 0x4b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x72, 0x50, 0x4b, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x4b, 0x12, 0x26,
+	0x0a, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x4b, 0x12, 0x24, 0x0a, 0x0d, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f,
+	0x75, 0x73, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x50,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x74, 0x61, 0x74, 0x65, 0x42, 0x2c, 0x5a, 0x2a,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x61, 0x6e, 0x61, 0x72,
+	0x74, 0x6f, 0x64, 0x65, 0x73, 0x6b, 0x2f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2d, 0x64, 0x65,
+	0x73, 0x69, 0x67, 0x6e, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_lists_events_proto_rawDescOnce sync.Once
+	file_lists_events_proto_rawDescData = file_lists_events_proto_rawDesc
+)
+
+func file_lists_events_proto_rawDescGZIP() []byte {
+	file_lists_events_proto_rawDescOnce.Do(func() {
+		file_lists_events_proto_rawDescData = protoimpl.X.CompressGZIP(file_lists_events_proto_rawDescData)
+	})
+	return file_lists_events_proto_rawDescData
+}
+
+var file_lists_events_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_lists_events_proto_goTypes = []interface{}{
+	(*ListDeleted)(nil),             // 0: domain.events.lists.v1.ListDeleted
+	(*SubscriberForgotten)(nil),     // 1: domain.events.lists.v1.SubscriberForgotten
+	(*SubscriberOptedIn)(nil),       // 2: domain.events.lists.v1.SubscriberOptedIn
+	(*SubscriberOptedOut)(nil),      // 3: domain.events.lists.v1.SubscriberOptedOut
+	(*SubscriptionExpired)(nil),     // 4: domain.events.lists.v1.SubscriptionExpired
+	(*SubscriberResubscribed)(nil),  // 5: domain.events.lists.v1.SubscriberResubscribed
+	(*ListExported)(nil),            // 6: domain.events.lists.v1.ListExported
+	(*SuppressionAdded)(nil),        // 7: domain.events.lists.v1.SuppressionAdded
+	(*SuppressionRemoved)(nil),      // 8: domain.events.lists.v1.SuppressionRemoved
+	(*SubscriptionSuppressed)(nil),  // 9: domain.events.lists.v1.SubscriptionSuppressed
+	(*SubscriptionBounced)(nil),     // 10: domain.events.lists.v1.SubscriptionBounced
+	(*SubscriptionComplained)(nil),  // 11: domain.events.lists.v1.SubscriptionComplained
+	(*SoftBounceRecorded)(nil),      // 12: domain.events.lists.v1.SoftBounceRecorded
+	(*OrganizationCreated)(nil),     // 13: domain.events.lists.v1.OrganizationCreated
+	(*OrganizationRenamed)(nil),     // 14: domain.events.lists.v1.OrganizationRenamed
+	(*ListCreated)(nil),             // 15: domain.events.lists.v1.ListCreated
+	(*ListRenamed)(nil),             // 16: domain.events.lists.v1.ListRenamed
+	(*ListSchemaChanged)(nil),       // 17: domain.events.lists.v1.ListSchemaChanged
+	(*SubscriberCreated)(nil),       // 18: domain.events.lists.v1.SubscriberCreated
+	(*SubscriberSubscribed)(nil),    // 19: domain.events.lists.v1.SubscriberSubscribed
+	(*SubscriberUnsubscribed)(nil),  // 20: domain.events.lists.v1.SubscriberUnsubscribed
+	(*SubscriptionDataChanged)(nil), // 21: domain.events.lists.v1.SubscriptionDataChanged
+	(*SoftBouncesReset)(nil),        // 22: domain.events.lists.v1.SoftBouncesReset
+	(*SubscriptionForgotten)(nil),   // 23: domain.events.lists.v1.SubscriptionForgotten
+	(*structpb.Struct)(nil),         // 24: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),   // 25: google.protobuf.Timestamp
+	(*structpb.ListValue)(nil),      // 26: google.protobuf.ListValue
+}
+var file_lists_events_proto_depIdxs = []int32{
+	24, // 0: domain.events.lists.v1.SubscriberResubscribed.Data:type_name -> google.protobuf.Struct
+	25, // 1: domain.events.lists.v1.SuppressionAdded.ExpiresAt:type_name -> google.protobuf.Timestamp
+	26, // 2: domain.events.lists.v1.ListSchemaChanged.Fields:type_name -> google.protobuf.ListValue
+	24, // 3: domain.events.lists.v1.SubscriberSubscribed.Data:type_name -> google.protobuf.Struct
+	24, // 4: domain.events.lists.v1.SubscriptionDataChanged.Data:type_name -> google.protobuf.Struct
+	5,  // [5:5] is the sub-list for method output_type
+	5,  // [5:5] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_lists_events_proto_init() }
+func file_lists_events_proto_init() {
+	if File_lists_events_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_lists_events_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeleted); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriberForgotten); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriberOptedIn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriberOptedOut); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionExpired); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriberResubscribed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExported); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SuppressionAdded); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SuppressionRemoved); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionSuppressed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionBounced); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionComplained); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SoftBounceRecorded); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrganizationCreated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrganizationRenamed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCreated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRenamed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSchemaChanged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriberCreated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriberSubscribed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriberUnsubscribed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionDataChanged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SoftBouncesReset); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lists_events_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionForgotten); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -350,7 +2378,7 @@ func file_lists_events_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_lists_events_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
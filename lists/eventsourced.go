@@ -0,0 +1,427 @@
+package lists
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/janartodesk/domain-design/pkg/eventstore"
+	"github.com/janartodesk/domain-design/pkg/outbox"
+	"github.com/uptrace/bun"
+	"google.golang.org/protobuf/proto"
+)
+
+// SnapshotInterval is the number of versions between the snapshots of an
+// event-sourced aggregate.
+const SnapshotInterval = 50
+
+// eventSourcedStore persists aggregates as event streams.
+//
+// The tables of stateStore are written along with the streams and serve the
+// lookups by anything but primary key. Aggregates found through them are
+// loaded from their streams.
+type eventSourcedStore struct {
+	readModel stateStore
+}
+
+// foldFunc applies an event to an aggregate. It reports whether the
+// aggregate still exists after the event.
+type foldFunc func(env *outbox.Envelope, event proto.Message) (bool, error)
+
+func (s eventSourcedStore) GetList(ctx context.Context, db bun.IDB, orgPK, listPK uuid.UUID) (*domain.List, error) {
+	list := &domain.List{}
+
+	if err := loadAggregate(ctx, db, AggregateList, listPK, list, func(env *outbox.Envelope, event proto.Message) (bool, error) {
+		return foldList(list, env, event)
+	}); err != nil {
+		return nil, err
+	}
+
+	if list.OrganizationPK != orgPK {
+		return nil, domain.ErrNotFound
+	}
+
+	return list, nil
+}
+
+func (s eventSourcedStore) SaveList(ctx context.Context, db bun.IDB, list *domain.List, env *outbox.Envelope) error {
+	if err := appendEvent(ctx, db, env); err != nil {
+		return err
+	}
+
+	if err := s.readModel.SaveList(ctx, db, list, env); err != nil {
+		return err
+	}
+
+	return snapshotAggregate(ctx, db, env, list)
+}
+
+func (s eventSourcedStore) DeleteList(ctx context.Context, db bun.IDB, list *domain.List, env *outbox.Envelope) error {
+	if err := appendEvent(ctx, db, env); err != nil {
+		return err
+	}
+
+	return s.readModel.DeleteList(ctx, db, list, env)
+}
+
+func (s eventSourcedStore) GetSubscriber(ctx context.Context, db bun.IDB, orgPK, subscriberPK uuid.UUID) (*domain.Subscriber, error) {
+	subscriber := &domain.Subscriber{}
+
+	if err := loadAggregate(ctx, db, AggregateSubscriber, subscriberPK, subscriber, func(env *outbox.Envelope, event proto.Message) (bool, error) {
+		return foldSubscriber(subscriber, env, event)
+	}); err != nil {
+		return nil, err
+	}
+
+	if subscriber.OrganizationPK != orgPK {
+		return nil, domain.ErrNotFound
+	}
+
+	return subscriber, nil
+}
+
+func (s eventSourcedStore) GetSubscriberByEmailAddress(ctx context.Context, db bun.IDB, orgPK uuid.UUID, addr domain.EmailAddress) (*domain.Subscriber, error) {
+	subscriber, err := s.readModel.GetSubscriberByEmailAddress(ctx, db, orgPK, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSubscriber(ctx, db, orgPK, subscriber.PK)
+}
+
+func (s eventSourcedStore) SaveSubscriber(ctx context.Context, db bun.IDB, subscriber *domain.Subscriber, env *outbox.Envelope) error {
+	if err := appendEvent(ctx, db, env); err != nil {
+		return err
+	}
+
+	if err := s.readModel.SaveSubscriber(ctx, db, subscriber, env); err != nil {
+		return err
+	}
+
+	return snapshotAggregate(ctx, db, env, subscriber)
+}
+
+func (s eventSourcedStore) GetSubscription(ctx context.Context, db bun.IDB, orgPK, listPK, subscriptionPK uuid.UUID) (*domain.Subscription, error) {
+	subscription := &domain.Subscription{}
+
+	if err := loadAggregate(ctx, db, AggregateSubscription, subscriptionPK, subscription, func(env *outbox.Envelope, event proto.Message) (bool, error) {
+		return foldSubscription(subscription, env, event)
+	}); err != nil {
+		return nil, err
+	}
+
+	if subscription.OrganizationPK != orgPK || subscription.ListPK != listPK {
+		return nil, domain.ErrNotFound
+	}
+
+	return subscription, nil
+}
+
+func (s eventSourcedStore) GetSubscriptionForSubscriber(ctx context.Context, db bun.IDB, orgPK, listPK, subscriberPK uuid.UUID) (*domain.Subscription, error) {
+	subscription, err := s.readModel.GetSubscriptionForSubscriber(ctx, db, orgPK, listPK, subscriberPK)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSubscription(ctx, db, orgPK, listPK, subscription.PK)
+}
+
+func (s eventSourcedStore) ListSubscriptionsForSubscriber(ctx context.Context, db bun.IDB, orgPK, subscriberPK uuid.UUID) ([]*domain.Subscription, error) {
+	subscriptions, err := s.readModel.ListSubscriptionsForSubscriber(ctx, db, orgPK, subscriberPK)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reloadSubscriptions(ctx, db, subscriptions)
+}
+
+func (s eventSourcedStore) ListOutdatedSubscriptions(ctx context.Context, db bun.IDB, orgPK, listPK uuid.UUID, schemaVersion uint32, after uuid.UUID, limit uint32) ([]*domain.Subscription, error) {
+	subscriptions, err := s.readModel.ListOutdatedSubscriptions(ctx, db, orgPK, listPK, schemaVersion, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reloadSubscriptions(ctx, db, subscriptions)
+}
+
+func (s eventSourcedStore) ListSuppressedSubscriptions(ctx context.Context, db bun.IDB, suppression *domain.Suppression, states []domain.SubscriptionState, after uuid.UUID, limit uint32) ([]*domain.Subscription, error) {
+	subscriptions, err := s.readModel.ListSuppressedSubscriptions(ctx, db, suppression, states, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reloadSubscriptions(ctx, db, subscriptions)
+}
+
+func (s eventSourcedStore) SaveSubscription(ctx context.Context, db bun.IDB, subscription *domain.Subscription, env *outbox.Envelope) error {
+	if err := appendEvent(ctx, db, env); err != nil {
+		return err
+	}
+
+	if err := s.readModel.SaveSubscription(ctx, db, subscription, env); err != nil {
+		return err
+	}
+
+	return snapshotAggregate(ctx, db, env, subscription)
+}
+
+func (s eventSourcedStore) DeleteSubscription(ctx context.Context, db bun.IDB, subscription *domain.Subscription, env *outbox.Envelope) error {
+	if err := appendEvent(ctx, db, env); err != nil {
+		return err
+	}
+
+	return s.readModel.DeleteSubscription(ctx, db, subscription, env)
+}
+
+// reloadSubscriptions loads subscriptions found through the read model from
+// their streams.
+func (s eventSourcedStore) reloadSubscriptions(ctx context.Context, db bun.IDB, subscriptions []*domain.Subscription) ([]*domain.Subscription, error) {
+	res := make([]*domain.Subscription, 0, len(subscriptions))
+
+	for _, subscription := range subscriptions {
+		subscription, err := s.GetSubscription(ctx, db, subscription.OrganizationPK, subscription.ListPK, subscription.PK)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, subscription)
+	}
+
+	return res, nil
+}
+
+// appendEvent appends an enveloped event to the stream of its aggregate. It
+// returns domain.ErrVersionConflict when the stream has moved past the version
+// the event follows.
+func appendEvent(ctx context.Context, db bun.IDB, env *outbox.Envelope) error {
+	if err := eventstore.Append(ctx, db, env); err != nil {
+		if errors.Is(err, eventstore.ErrVersionConflict) {
+			return domain.ErrVersionConflict
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// loadAggregate rebuilds an aggregate from the latest snapshot of its stream
+// and the events after it. It returns domain.ErrNotFound for aggregates that
+// were never saved or have been deleted.
+func loadAggregate(ctx context.Context, db bun.IDB, streamType string, streamID uuid.UUID, aggregate interface{}, fold foldFunc) error {
+	var (
+		after  uint32
+		exists bool
+	)
+
+	snapshot, err := eventstore.LoadSnapshot(ctx, db, streamType, streamID)
+	switch err {
+	case nil:
+		if err := json.Unmarshal(snapshot.State, aggregate); err != nil {
+			return err
+		}
+
+		after = snapshot.Version
+		exists = true
+	case sql.ErrNoRows:
+	default:
+		return err
+	}
+
+	envs, err := eventstore.Load(ctx, db, streamType, streamID, after)
+	if err != nil {
+		return err
+	}
+
+	for _, env := range envs {
+		event, err := outbox.Unwrap(env)
+		if err != nil {
+			return err
+		}
+
+		if exists, err = fold(env, event); err != nil {
+			return err
+		}
+	}
+
+	if !exists {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// snapshotAggregate snapshots an aggregate every SnapshotInterval versions.
+//
+// Forgotten aggregates are snapshotted regardless and the events up to the
+// snapshot are truncated, which erases the personal data they carried.
+func snapshotAggregate(ctx context.Context, db bun.IDB, env *outbox.Envelope, aggregate interface{}) error {
+	meta := env.Metadata()
+
+	forgotten := env.GetPayload().MessageIs((*SubscriberForgotten)(nil)) ||
+		env.GetPayload().MessageIs((*SubscriptionForgotten)(nil))
+
+	if !forgotten && meta.AggregateVersion%SnapshotInterval != 0 {
+		return nil
+	}
+
+	state, err := json.Marshal(aggregate)
+	if err != nil {
+		return err
+	}
+
+	if err := eventstore.SaveSnapshot(ctx, db, meta.AggregateType, meta.AggregateID, meta.AggregateVersion, state); err != nil {
+		return err
+	}
+
+	if !forgotten {
+		return nil
+	}
+
+	return eventstore.Truncate(ctx, db, meta.AggregateType, meta.AggregateID, meta.AggregateVersion)
+}
+
+func foldList(list *domain.List, env *outbox.Envelope, event proto.Message) (bool, error) {
+	switch e := event.(type) {
+	case *ListCreated:
+		*list = domain.List{
+			PK:             uuid.FromBytesOrNil(e.ListPK),
+			OrganizationPK: uuid.FromBytesOrNil(e.OrganizationPK),
+			Title:          e.Title,
+			Schema:         domain.Schema{Version: 1},
+		}
+	case *ListRenamed:
+		list.Title = e.Title
+	case *ListSchemaChanged:
+		fields, err := listFields(e.Fields)
+		if err != nil {
+			return false, err
+		}
+
+		list.Schema = domain.Schema{
+			Version: e.SchemaVersion,
+			Fields:  fields,
+		}
+	case *ListDeleted:
+		return false, nil
+	default:
+		return false, unexpectedEvent(env)
+	}
+
+	list.Version = env.GetAggregateVersion()
+
+	return true, nil
+}
+
+func foldSubscriber(subscriber *domain.Subscriber, env *outbox.Envelope, event proto.Message) (bool, error) {
+	switch e := event.(type) {
+	case *SubscriberCreated:
+		*subscriber = domain.Subscriber{
+			PK:             uuid.FromBytesOrNil(e.SubscriberPK),
+			OrganizationPK: uuid.FromBytesOrNil(e.OrganizationPK),
+			EmailAddress:   domain.EmailAddress(e.EmailAddress),
+		}
+	case *SubscriberForgotten:
+		forgotten, err := domain.ForgetSubscriber(*subscriber)
+		if err != nil {
+			return false, err
+		}
+
+		*subscriber = *forgotten
+	case *SoftBounceRecorded:
+		subscriber.SoftBounces = e.Count
+	case *SoftBouncesReset:
+		subscriber.SoftBounces = 0
+	default:
+		return false, unexpectedEvent(env)
+	}
+
+	subscriber.Version = env.GetAggregateVersion()
+
+	return true, nil
+}
+
+func foldSubscription(subscription *domain.Subscription, env *outbox.Envelope, event proto.Message) (bool, error) {
+	now := env.GetOccurredAt().AsTime()
+
+	switch e := event.(type) {
+	case *SubscriberSubscribed:
+		state := domain.SubscriptionState(e.State)
+		changedAt := subscription.StateChangedAt
+
+		if subscription.State != state {
+			changedAt = now
+		}
+
+		*subscription = domain.Subscription{
+			PK:             uuid.FromBytesOrNil(e.SubscriptionPK),
+			OrganizationPK: uuid.FromBytesOrNil(e.OrganizationPK),
+			SubscriberPK:   uuid.FromBytesOrNil(e.SubscriberPK),
+			ListPK:         uuid.FromBytesOrNil(e.ListPK),
+			EmailAddress:   domain.EmailAddress(e.EmailAddress),
+			Data:           structData(e.Data),
+			SchemaVersion:  e.SchemaVersion,
+			State:          state,
+			StateChangedAt: changedAt,
+		}
+	case *SubscriberOptedIn:
+		setSubscriptionState(subscription, domain.SubscriptionActive, now)
+	case *SubscriberResubscribed:
+		// Events recorded before resubscriptions carried data leave it as is.
+		if e.Data != nil {
+			subscription.Data = structData(e.Data)
+			subscription.SchemaVersion = e.SchemaVersion
+		}
+
+		setSubscriptionState(subscription, domain.SubscriptionActive, now)
+	case *SubscriberUnsubscribed, *SubscriberOptedOut:
+		setSubscriptionState(subscription, domain.SubscriptionUnsubscribed, now)
+	case *SubscriptionBounced:
+		setSubscriptionState(subscription, domain.SubscriptionBounced, now)
+	case *SubscriptionComplained:
+		setSubscriptionState(subscription, domain.SubscriptionComplained, now)
+	case *SubscriptionSuppressed:
+		setSubscriptionState(subscription, domain.SubscriptionSuppressed, now)
+	case *SubscriptionDataChanged:
+		subscription.Data = structData(e.Data)
+		subscription.SchemaVersion = e.SchemaVersion
+	case *SubscriptionForgotten:
+		tombstone, err := domain.ForgetSubscriber(domain.Subscriber{PK: subscription.SubscriberPK})
+		if err != nil {
+			return false, err
+		}
+
+		forgotten, err := domain.ForgetSubscription(*subscription, *tombstone, now)
+		if err != nil {
+			return false, err
+		}
+
+		*subscription = *forgotten
+	case *SubscriptionExpired:
+		if e.State == "" {
+			return false, nil
+		}
+
+		setSubscriptionState(subscription, domain.SubscriptionState(e.State), now)
+	default:
+		return false, unexpectedEvent(env)
+	}
+
+	subscription.Version = env.GetAggregateVersion()
+
+	return true, nil
+}
+
+func setSubscriptionState(subscription *domain.Subscription, state domain.SubscriptionState, now time.Time) {
+	subscription.State = state
+	subscription.StateChangedAt = now
+}
+
+func unexpectedEvent(env *outbox.Envelope) error {
+	return fmt.Errorf("unexpected %s event in %s stream", env.GetPayload().MessageName(), env.GetAggregateType())
+}
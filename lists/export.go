@@ -0,0 +1,383 @@
+package lists
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/janartodesk/domain-design/lists/model"
+)
+
+// ExportFormat is the file format of a list export.
+type ExportFormat string
+
+// Export formats.
+const (
+	ExportCSV       ExportFormat = "csv"
+	ExportJSONLines ExportFormat = "jsonl"
+)
+
+// Export columns that are not subscription data fields.
+const (
+	ExportColumnEmailAddress   = "email"
+	ExportColumnState          = "state"
+	ExportColumnStateChangedAt = "state_changed_at"
+	ExportColumnSubscriptionPK = "subscription_pk"
+	ExportColumnSubscriberPK   = "subscriber_pk"
+)
+
+// ExportDataPrefix selects a subscription data field whose name is also an
+// export column, as in "data.state". CSV headers name such fields the same way.
+const ExportDataPrefix = "data."
+
+// exportDataKey is the key JSON Lines exports nest subscription data under.
+const exportDataKey = "data"
+
+// exportColumn is an export column or a subscription data field.
+type exportColumn struct {
+	name  string
+	field bool
+}
+
+// header returns the CSV header of the column.
+func (c exportColumn) header() string {
+	if c.field && isExportColumn(c.name) {
+		return ExportDataPrefix + c.name
+	}
+
+	return c.name
+}
+
+func isExportColumn(name string) bool {
+	switch name {
+	case ExportColumnEmailAddress,
+		ExportColumnState,
+		ExportColumnStateChangedAt,
+		ExportColumnSubscriptionPK,
+		ExportColumnSubscriberPK:
+		return true
+	}
+
+	return false
+}
+
+// parseExportColumn parses a column of ExportOptions.
+func parseExportColumn(name string) exportColumn {
+	if strings.HasPrefix(name, ExportDataPrefix) {
+		return exportColumn{name: strings.TrimPrefix(name, ExportDataPrefix), field: true}
+	}
+
+	return exportColumn{name: name, field: !isExportColumn(name)}
+}
+
+// ExportOptions configures a list export.
+type ExportOptions struct {
+	Format ExportFormat
+	// Columns lists the exported columns in order. A column is either one of
+	// the ExportColumn constants or the name of a subscription data field,
+	// optionally prefixed with ExportDataPrefix. It defaults to the email
+	// address, state and every field of the list's schema.
+	Columns []string
+	// States exports only subscriptions in any of the states when set.
+	States []domain.SubscriptionState
+}
+
+// exportWriter writes exported subscriptions in an export format.
+type exportWriter interface {
+	Write(values []interface{}) error
+	Flush() error
+}
+
+// ExportList writes the subscriptions of a list to w and returns the number of
+// subscriptions written.
+//
+// Subscriptions are streamed from a consistent snapshot and written as they
+// are read, so memory use stays flat regardless of the size of the list.
+// CSV exports flatten subscription data into columns, while JSON Lines exports
+// nest it under a "data" key. A ListExported event is published once the
+// export has been written, as an export aggregate of its own so that the
+// version of the list does not change.
+func (u *Usecase) ExportList(ctx context.Context, orgPK, listPK uuid.UUID, w io.Writer, opts ExportOptions) (uint64, error) {
+	if err := u.requireDB(); err != nil {
+		return 0, err
+	}
+
+	list, err := model.GetList(ctx, u.db, orgPK, listPK)
+	if err != nil {
+		return 0, err
+	}
+
+	columns := make([]exportColumn, 0, len(opts.Columns))
+
+	for _, name := range opts.Columns {
+		columns = append(columns, parseExportColumn(name))
+	}
+
+	if len(columns) == 0 {
+		columns = append(columns, exportColumn{name: ExportColumnEmailAddress}, exportColumn{name: ExportColumnState})
+
+		for _, field := range list.Schema.Fields {
+			columns = append(columns, exportColumn{name: field.Name, field: true})
+		}
+	}
+
+	if opts.Format == "" {
+		opts.Format = ExportCSV
+	}
+
+	var out exportWriter
+
+	switch opts.Format {
+	case ExportCSV:
+		if out, err = newCSVExportWriter(w, columns); err != nil {
+			return 0, err
+		}
+	case ExportJSONLines:
+		out = &jsonLinesExportWriter{w: w, columns: columns}
+	default:
+		return 0, &domain.ValidationError{Message: fmt.Sprintf("unsupported export format %q", opts.Format)}
+	}
+
+	var n uint64
+
+	filter := domain.SubscriptionFilter{
+		ListPK: list.PK,
+		States: opts.States,
+	}
+
+	if err := u.StreamSubscriptions(ctx, orgPK, filter, func(subscription *domain.Subscription) error {
+		values := make([]interface{}, len(columns))
+
+		for i, column := range columns {
+			values[i] = exportValue(subscription, column)
+		}
+
+		n++
+
+		return out.Write(values)
+	}); err != nil {
+		return n, err
+	}
+
+	if err := out.Flush(); err != nil {
+		return n, err
+	}
+
+	headers := make([]string, len(columns))
+
+	for i, column := range columns {
+		headers[i] = column.header()
+	}
+
+	states := []string{}
+
+	for _, state := range opts.States {
+		states = append(states, string(state))
+	}
+
+	if err := u.transaction(ctx, func(tx Transaction) error {
+		return enqueue(ctx, tx, &ListExported{
+			ListPK:         list.PK.Bytes(),
+			OrganizationPK: list.OrganizationPK.Bytes(),
+			Format:         string(opts.Format),
+			Columns:        headers,
+			States:         states,
+			Count:          n,
+		}, exportEvent(list))
+	}); err != nil {
+		return n, err
+	}
+
+	return n, nil
+}
+
+func exportValue(subscription *domain.Subscription, column exportColumn) interface{} {
+	if column.field {
+		return subscription.Data[column.name]
+	}
+
+	switch column.name {
+	case ExportColumnEmailAddress:
+		return string(subscription.EmailAddress)
+	case ExportColumnState:
+		return string(subscription.State)
+	case ExportColumnStateChangedAt:
+		return subscription.StateChangedAt.UTC().Format(time.RFC3339)
+	case ExportColumnSubscriptionPK:
+		return subscription.PK.String()
+	case ExportColumnSubscriberPK:
+		return subscription.SubscriberPK.String()
+	}
+
+	return nil
+}
+
+type csvExportWriter struct {
+	w       *csv.Writer
+	columns []exportColumn
+	record  []string
+}
+
+func newCSVExportWriter(w io.Writer, columns []exportColumn) (*csvExportWriter, error) {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(columns))
+
+	for i, column := range columns {
+		header[i] = column.header()
+
+		if column.field {
+			header[i] = escapeCSVCell(header[i])
+		}
+	}
+
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &csvExportWriter{
+		w:       cw,
+		columns: columns,
+		record:  make([]string, len(columns)),
+	}, nil
+}
+
+func (w *csvExportWriter) Write(values []interface{}) error {
+	for i, v := range values {
+		w.record[i] = flattenExportValue(v)
+
+		// Only free-form subscription data is escaped. Email addresses and
+		// the other export columns are written as they are, so that they
+		// can be imported again unchanged.
+		if _, ok := v.(float64); !ok && w.columns[i].field {
+			w.record[i] = escapeCSVCell(w.record[i])
+		}
+	}
+
+	return w.w.Write(w.record)
+}
+
+func (w *csvExportWriter) Flush() error {
+	w.w.Flush()
+
+	return w.w.Error()
+}
+
+// flattenExportValue formats a subscription data value as a CSV cell. Lists
+// are joined with commas, which ImportSubscribers splits again only for
+// multi-select fields of the list's schema.
+func flattenExportValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		return strings.Join(v, ",")
+	case []interface{}:
+		items := make([]string, len(v))
+
+		for i, item := range v {
+			items[i] = flattenExportValue(item)
+		}
+
+		return strings.Join(items, ",")
+	case map[string]interface{}:
+		b, _ := json.Marshal(v)
+
+		return string(b)
+	}
+
+	return fmt.Sprint(v)
+}
+
+// escapeCSVCell prefixes a cell that a spreadsheet would evaluate as a formula
+// with a quote, so that it is shown as text instead.
+func escapeCSVCell(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@", rune(cell[0])) {
+		return "'" + cell
+	}
+
+	return cell
+}
+
+type jsonLinesExportWriter struct {
+	w       io.Writer
+	columns []exportColumn
+}
+
+func (w *jsonLinesExportWriter) Write(values []interface{}) error {
+	obj := orderedObject{}
+	data := orderedObject{}
+
+	for i, v := range values {
+		if w.columns[i].field {
+			data = append(data, orderedMember{key: w.columns[i].name, value: v})
+		} else {
+			obj = append(obj, orderedMember{key: w.columns[i].name, value: v})
+		}
+	}
+
+	obj = append(obj, orderedMember{key: exportDataKey, value: data})
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.w.Write(append(b, '\n'))
+
+	return err
+}
+
+func (w *jsonLinesExportWriter) Flush() error {
+	return nil
+}
+
+// orderedObject is a JSON object whose members are encoded in order.
+type orderedObject []orderedMember
+
+type orderedMember struct {
+	key   string
+	value interface{}
+}
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,54 @@
+package lists
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestJSONLinesExportWriterNestsDataInOrder(t *testing.T) {
+	buf := bytes.Buffer{}
+	w := &jsonLinesExportWriter{w: &buf, columns: []exportColumn{
+		parseExportColumn(ExportColumnState),
+		parseExportColumn(ExportColumnEmailAddress),
+		parseExportColumn("name"),
+		parseExportColumn(ExportDataPrefix + ExportColumnEmailAddress),
+	}}
+
+	if err := w.Write([]interface{}{"active", "jane.doe@example.com", "Jane", "jane@example.org"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"state":"active","email":"jane.doe@example.com","data":{"name":"Jane","email":"jane@example.org"}}` + "\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("wrote %s, want %s", got, want)
+	}
+}
+
+func TestCSVExportWriterEscapesFormulasInData(t *testing.T) {
+	buf := bytes.Buffer{}
+
+	w, err := newCSVExportWriter(&buf, []exportColumn{
+		parseExportColumn(ExportColumnEmailAddress),
+		parseExportColumn("=total"),
+		parseExportColumn("score"),
+		parseExportColumn(ExportDataPrefix + ExportColumnState),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Write([]interface{}{"-jane@example.com", "=1+2", -3.5, "-x"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "email,'=total,score,data.state\n-jane@example.com,'=1+2,-3.5,'-x\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("wrote %q, want %q", got, want)
+	}
+}
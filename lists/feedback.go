@@ -0,0 +1,228 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/janartodesk/domain-design/lists/feedback"
+)
+
+// DefaultSoftBounceThreshold is the number of soft bounces after which a
+// subscriber is treated as hard bounced when SoftBounceThreshold is not set.
+const DefaultSoftBounceThreshold = 5
+
+var _ feedback.Processor = (*Usecase)(nil)
+
+// ProcessFeedback applies bounce and complaint reports to the subscribers they
+// were resolved to. It implements feedback.Processor.
+//
+// Hard bounces move the subscriber's subscriptions to the bounced state and
+// complaints to the complained state, and both suppress the subscriber's
+// address within the organization. Soft bounces are counted until a delivery
+// to the subscriber succeeds, and a subscriber reaching SoftBounceThreshold is
+// treated as hard bounced. Reports about addresses that are not subscribers
+// are ignored.
+//
+// Processed reports are recorded by the message ID of the original message, so
+// that a message delivered again has no further effect. Reports without a
+// message ID are applied every time.
+//
+// The reports of a message are applied in a single transaction.
+func (u *Usecase) ProcessFeedback(ctx context.Context, reports []*feedback.Report) error {
+	return u.transaction(ctx, func(tx Transaction) error {
+		for _, report := range reports {
+			if err := u.processFeedbackReport(ctx, tx, report); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (u *Usecase) processFeedbackReport(ctx context.Context, tx Transaction, report *feedback.Report) error {
+	subscriber, err := tx.Subscribers().GetSubscriberByEmailAddress(ctx, report.OrganizationPK, report.Recipient)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if report.MessageID != "" {
+		recorded, err := tx.Feedback().RecordReport(ctx, report.OrganizationPK, report.MessageID, report.Recipient, string(report.Type))
+		if err != nil {
+			return err
+		}
+
+		if !recorded {
+			return nil
+		}
+	}
+
+	switch report.Type {
+	case feedback.HardBounce:
+		return u.bounceSubscriber(ctx, tx, subscriber, report)
+	case feedback.SoftBounce:
+		return u.recordSoftBounce(ctx, tx, subscriber, report)
+	case feedback.Complaint:
+		return u.complainSubscriber(ctx, tx, subscriber, report)
+	case feedback.Delivered:
+		_, err := resetSoftBounces(ctx, tx, subscriber)
+
+		return err
+	}
+
+	return nil
+}
+
+func (u *Usecase) bounceSubscriber(ctx context.Context, tx Transaction, subscriber *domain.Subscriber, report *feedback.Report) error {
+	subscriptions, err := tx.Subscriptions().ListSubscriptionsForSubscriber(ctx, subscriber.OrganizationPK, subscriber.PK)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, subscription := range subscriptions {
+		if !subscription.State.CanTransition(domain.SubscriptionBounced) {
+			continue
+		}
+
+		subscription, err = domain.BounceSubscription(*subscription, now)
+		if err != nil {
+			return err
+		}
+
+		if err := saveSubscription(ctx, tx, subscription, &SubscriptionBounced{
+			SubscriptionPK: subscription.PK.Bytes(),
+			SubscriberPK:   subscription.SubscriberPK.Bytes(),
+			ListPK:         subscription.ListPK.Bytes(),
+			OrganizationPK: subscription.OrganizationPK.Bytes(),
+			Status:         report.Status,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if subscriber, err = resetSoftBounces(ctx, tx, subscriber); err != nil {
+		return err
+	}
+
+	return u.suppressSubscriber(ctx, tx, subscriber, domain.SuppressionBounce)
+}
+
+// resetSoftBounces clears the soft bounce count of a subscriber that has any.
+func resetSoftBounces(ctx context.Context, tx Transaction, subscriber *domain.Subscriber) (*domain.Subscriber, error) {
+	if subscriber.SoftBounces == 0 {
+		return subscriber, nil
+	}
+
+	subscriber, err := domain.ResetSoftBounces(*subscriber)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := saveSubscriber(ctx, tx, subscriber, &SoftBouncesReset{
+		SubscriberPK:   subscriber.PK.Bytes(),
+		OrganizationPK: subscriber.OrganizationPK.Bytes(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return subscriber, nil
+}
+
+func (u *Usecase) recordSoftBounce(ctx context.Context, tx Transaction, subscriber *domain.Subscriber, report *feedback.Report) error {
+	subscriber, err := domain.RecordSoftBounce(*subscriber)
+	if err != nil {
+		return err
+	}
+
+	if err := saveSubscriber(ctx, tx, subscriber, &SoftBounceRecorded{
+		SubscriberPK:   subscriber.PK.Bytes(),
+		OrganizationPK: subscriber.OrganizationPK.Bytes(),
+		Count:          subscriber.SoftBounces,
+		Status:         report.Status,
+	}); err != nil {
+		return err
+	}
+
+	threshold := u.SoftBounceThreshold
+	if threshold == 0 {
+		threshold = DefaultSoftBounceThreshold
+	}
+
+	if subscriber.SoftBounces < threshold {
+		return nil
+	}
+
+	return u.bounceSubscriber(ctx, tx, subscriber, report)
+}
+
+func (u *Usecase) complainSubscriber(ctx context.Context, tx Transaction, subscriber *domain.Subscriber, report *feedback.Report) error {
+	subscriptions, err := tx.Subscriptions().ListSubscriptionsForSubscriber(ctx, subscriber.OrganizationPK, subscriber.PK)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, subscription := range subscriptions {
+		if !subscription.State.CanTransition(domain.SubscriptionComplained) {
+			continue
+		}
+
+		subscription, err = domain.ComplainSubscription(*subscription, now)
+		if err != nil {
+			return err
+		}
+
+		if err := saveSubscription(ctx, tx, subscription, &SubscriptionComplained{
+			SubscriptionPK: subscription.PK.Bytes(),
+			SubscriberPK:   subscription.SubscriberPK.Bytes(),
+			ListPK:         subscription.ListPK.Bytes(),
+			OrganizationPK: subscription.OrganizationPK.Bytes(),
+			FeedbackType:   report.FeedbackType,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return u.suppressSubscriber(ctx, tx, subscriber, domain.SuppressionComplaint)
+}
+
+// suppressSubscriber suppresses the address of a subscriber within their
+// organization unless it is suppressed already.
+func (u *Usecase) suppressSubscriber(ctx context.Context, tx Transaction, subscriber *domain.Subscriber, reason domain.SuppressionReason) error {
+	err := checkSuppressions(ctx, tx, subscriber.OrganizationPK, subscriber.EmailAddress)
+	if err != nil {
+		var suppressed *domain.SuppressedError
+		if errors.As(err, &suppressed) {
+			return nil
+		}
+
+		return err
+	}
+
+	suppression, err := domain.CreateSuppression(subscriber.OrganizationPK, string(subscriber.EmailAddress), reason, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	if err := u.createSuppression(ctx, tx, suppression); err != nil {
+		return err
+	}
+
+	for after, more := uuid.Nil, true; more; {
+		if after, more, err = u.suppressSubscriptions(ctx, tx, suppression, after); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,301 @@
+// Package feedback parses delivery status notifications and feedback loop
+// reports returned by mailbox providers.
+package feedback
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+)
+
+// OrganizationHeader identifies the organization that sent an original
+// message. The sending platform adds it to every message.
+const OrganizationHeader = "X-Organization-PK"
+
+// ErrMalformedReport is returned for messages that are not a delivery status
+// notification nor a feedback report, or that cannot be attributed to a
+// recipient of an organization.
+var ErrMalformedReport error = &domain.ValidationError{Message: "malformed feedback report"}
+
+// ReportType is the kind of feedback a report carries.
+type ReportType string
+
+// Report types.
+const (
+	HardBounce ReportType = "hard_bounce"
+	SoftBounce ReportType = "soft_bounce"
+	Complaint  ReportType = "complaint"
+	Delivered  ReportType = "delivered"
+)
+
+// Report is the feedback about a single recipient of an original message.
+type Report struct {
+	Type           ReportType
+	Recipient      domain.EmailAddress
+	OrganizationPK uuid.UUID
+	// Status is the enhanced status code of a bounce, such as 5.1.1.
+	Status string
+	// Diagnostic is the diagnostic of the remote server for a bounce.
+	Diagnostic string
+	// FeedbackType is the feedback type of a complaint, such as abuse.
+	FeedbackType string
+	// MessageID is the message ID of the original message.
+	MessageID string
+}
+
+// message is a multipart/report message broken into its parts.
+type message struct {
+	reportType string
+	// fields holds the header blocks of the machine-readable part.
+	fields []textproto.MIMEHeader
+	// original holds the headers of the original message.
+	original textproto.MIMEHeader
+}
+
+// Parse parses a raw MIME message holding a delivery status notification of
+// RFC 3464 or a feedback report of RFC 5965 into reports.
+//
+// A notification yields a report for every recipient that failed, was delayed
+// or was delivered. Feedback other than complaints yields no reports.
+func Parse(r io.Reader) ([]*Report, error) {
+	m, err := readMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	orgPK, err := uuid.FromString(strings.TrimSpace(m.original.Get(OrganizationHeader)))
+	if err != nil || orgPK == uuid.Nil {
+		return nil, fmt.Errorf("%w: original message has no %s header", ErrMalformedReport, OrganizationHeader)
+	}
+
+	messageID := strings.TrimSpace(m.original.Get("Message-Id"))
+
+	var reports []*Report
+
+	switch m.reportType {
+	case "delivery-status":
+		reports, err = parseDeliveryStatus(m)
+	case "feedback-report":
+		reports, err = parseFeedbackReport(m)
+	default:
+		return nil, fmt.Errorf("%w: unsupported report type %q", ErrMalformedReport, m.reportType)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, report := range reports {
+		report.OrganizationPK = orgPK
+		report.MessageID = messageID
+	}
+
+	return reports, nil
+}
+
+func readMessage(r io.Reader) (*message, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedReport, err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/report" {
+		return nil, fmt.Errorf("%w: not a multipart/report message", ErrMalformedReport)
+	}
+
+	m := &message{
+		reportType: strings.ToLower(params["report-type"]),
+		original:   textproto.MIMEHeader{},
+	}
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformedReport, err)
+		}
+
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		body := decodePart(part)
+
+		switch partType {
+		case "message/delivery-status", "message/global-delivery-status", "message/feedback-report":
+			if m.fields, err = readFieldBlocks(body); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrMalformedReport, err)
+			}
+		case "message/rfc822", "message/global", "text/rfc822-headers", "message/global-headers":
+			// The original message may be truncated, so headers that were
+			// read before an error are kept.
+			h, _ := textproto.NewReader(bufio.NewReader(body)).ReadMIMEHeader()
+			for k, v := range h {
+				m.original[k] = v
+			}
+		}
+	}
+
+	if len(m.fields) == 0 {
+		return nil, fmt.Errorf("%w: missing machine-readable part", ErrMalformedReport)
+	}
+
+	return m, nil
+}
+
+// decodePart undoes the base64 transfer encoding of a part. Quoted-printable
+// parts are decoded by the multipart reader already.
+func decodePart(part *multipart.Part) io.Reader {
+	if strings.EqualFold(part.Header.Get("Content-Transfer-Encoding"), "base64") {
+		return base64.NewDecoder(base64.StdEncoding, part)
+	}
+
+	return part
+}
+
+// readFieldBlocks reads blocks of header fields separated by blank lines.
+func readFieldBlocks(r io.Reader) ([]textproto.MIMEHeader, error) {
+	tp := textproto.NewReader(bufio.NewReader(r))
+	blocks := []textproto.MIMEHeader{}
+
+	for {
+		h, err := tp.ReadMIMEHeader()
+		if len(h) > 0 {
+			blocks = append(blocks, h)
+		}
+
+		if err == io.EOF {
+			return blocks, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseDeliveryStatus reads the per-recipient fields of a delivery status
+// notification.
+func parseDeliveryStatus(m *message) ([]*Report, error) {
+	reports := []*Report{}
+
+	for _, fields := range m.fields {
+		recipient := fields.Get("Final-Recipient")
+		if recipient == "" {
+			recipient = fields.Get("Original-Recipient")
+		}
+
+		if recipient == "" {
+			// Per-message fields.
+			continue
+		}
+
+		status := statusCode(fields.Get("Status"))
+
+		var t ReportType
+
+		switch action := strings.ToLower(strings.TrimSpace(fields.Get("Action"))); {
+		case action == "failed" && strings.HasPrefix(status, "5."):
+			t = HardBounce
+		case action == "failed" || action == "delayed":
+			t = SoftBounce
+		case action == "delivered":
+			t = Delivered
+		default:
+			continue
+		}
+
+		addr, err := parseRecipient(recipient)
+		if err != nil {
+			return nil, err
+		}
+
+		reports = append(reports, &Report{
+			Type:       t,
+			Recipient:  addr,
+			Status:     status,
+			Diagnostic: typedValue(fields.Get("Diagnostic-Code")),
+		})
+	}
+
+	return reports, nil
+}
+
+// parseFeedbackReport reads a feedback report. Only abuse and fraud reports
+// are complaints.
+func parseFeedbackReport(m *message) ([]*Report, error) {
+	fields := m.fields[0]
+
+	feedbackType := strings.ToLower(strings.TrimSpace(fields.Get("Feedback-Type")))
+	if feedbackType != "abuse" && feedbackType != "fraud" {
+		return []*Report{}, nil
+	}
+
+	recipient := fields.Get("Original-Rcpt-To")
+	if recipient == "" {
+		recipient = m.original.Get("To")
+	}
+
+	if recipient == "" {
+		return nil, fmt.Errorf("%w: complaint has no recipient", ErrMalformedReport)
+	}
+
+	addr, err := parseRecipient(recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*Report{{
+		Type:         Complaint,
+		Recipient:    addr,
+		FeedbackType: feedbackType,
+	}}, nil
+}
+
+// parseRecipient parses a recipient given as an address type and address, as
+// in DSN recipient fields, or as a plain mailbox.
+func parseRecipient(s string) (domain.EmailAddress, error) {
+	s = typedValue(s)
+
+	if a, err := mail.ParseAddress(s); err == nil {
+		s = a.Address
+	}
+
+	addr, err := domain.ParseEmailAddress(strings.Trim(s, "<>"))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrMalformedReport, err)
+	}
+
+	return addr, nil
+}
+
+// typedValue strips the type of a "type; value" field such as
+// "rfc822; user@example.com".
+func typedValue(s string) string {
+	if i := strings.IndexByte(s, ';'); i >= 0 {
+		s = s[i+1:]
+	}
+
+	return strings.TrimSpace(s)
+}
+
+// statusCode returns the status code of a status field, dropping any comment.
+func statusCode(s string) string {
+	if fields := strings.Fields(s); len(fields) > 0 {
+		return fields[0]
+	}
+
+	return ""
+}
@@ -0,0 +1,94 @@
+package feedback
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofrs/uuid"
+)
+
+var testOrganizationPK = uuid.Must(uuid.FromString("5d1f8a2e-3c2b-4d7a-9f6e-0a1b2c3d4e5f"))
+
+func parseFixture(t *testing.T, name string) ([]*Report, error) {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    []Report
+	}{
+		{
+			fixture: "dsn.eml",
+			want: []Report{
+				{Type: HardBounce, Recipient: "Gone@example.org", Status: "5.1.1", MessageID: "<weekly-42@sender.example.com>"},
+				{Type: SoftBounce, Recipient: "full@example.org", Status: "4.2.2", MessageID: "<weekly-42@sender.example.com>"},
+				{Type: Delivered, Recipient: "jane@xn--mnchen-3ya.de", Status: "2.0.0", MessageID: "<weekly-42@sender.example.com>"},
+			},
+		},
+		{
+			fixture: "arf.eml",
+			want: []Report{
+				{Type: Complaint, Recipient: "complainer@example.org", FeedbackType: "abuse", MessageID: "<weekly-43@sender.example.com>"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			reports, err := parseFixture(t, tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(reports) != len(tt.want) {
+				t.Fatalf("parsed %d reports, want %d", len(reports), len(tt.want))
+			}
+
+			for i, want := range tt.want {
+				got := reports[i]
+
+				if got.Type != want.Type || got.Recipient != want.Recipient || got.Status != want.Status {
+					t.Errorf("report %d is %s %s %q, want %s %s %q", i, got.Type, got.Recipient, got.Status, want.Type, want.Recipient, want.Status)
+				}
+
+				if got.FeedbackType != want.FeedbackType {
+					t.Errorf("report %d has feedback type %q, want %q", i, got.FeedbackType, want.FeedbackType)
+				}
+
+				if got.OrganizationPK != testOrganizationPK {
+					t.Errorf("report %d has organization %s, want %s", i, got.OrganizationPK, testOrganizationPK)
+				}
+
+				if got.MessageID != want.MessageID {
+					t.Errorf("report %d has message ID %q, want %q", i, got.MessageID, want.MessageID)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRejectsMalformedReports(t *testing.T) {
+	for _, fixture := range []string{"no-organization.eml", "not-a-report.eml"} {
+		t.Run(fixture, func(t *testing.T) {
+			reports, err := parseFixture(t, fixture)
+			if !errors.Is(err, ErrMalformedReport) {
+				t.Fatalf("parsing returned %v, want %v", err, ErrMalformedReport)
+			}
+
+			if reports != nil {
+				t.Errorf("parsed %d reports from a malformed message", len(reports))
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package feedback
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Subdirectories of a DirectorySource that messages are moved to once read.
+const (
+	ProcessedDir = "processed"
+	FailedDir    = "failed"
+)
+
+// maxMessageSize is the largest report message accepted over HTTP.
+const maxMessageSize = 10 << 20
+
+// Processor applies the reports parsed from a message.
+type Processor interface {
+	ProcessFeedback(ctx context.Context, reports []*Report) error
+}
+
+// DirectorySource reads report messages dropped into a directory, one message
+// per file.
+//
+// Files are moved to the processed subdirectory once their reports have been
+// applied, and to the failed subdirectory when they cannot be parsed. Files
+// whose reports fail to apply are left in place and retried. Hidden files are
+// ignored, so messages can be written under a dot-prefixed name and renamed
+// once complete.
+type DirectorySource struct {
+	dir       string
+	processor Processor
+
+	// OnError is called by Run with the errors processing the directory
+	// fails with.
+	OnError func(error)
+}
+
+// NewDirectorySource creates a report source reading a directory.
+func NewDirectorySource(dir string, processor Processor) *DirectorySource {
+	return &DirectorySource{
+		dir:       dir,
+		processor: processor,
+	}
+}
+
+// Run processes report messages until the context is cancelled. Errors are
+// reported to OnError, and the messages that failed are retried on the next
+// run.
+func (s *DirectorySource) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Process(ctx); err != nil && ctx.Err() == nil && s.OnError != nil {
+			s.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Process processes the report messages currently in the directory, oldest
+// first, and returns the number of messages processed.
+//
+// The returned error is the first error a message failed to apply with.
+// Processing continues with the next message regardless.
+func (s *DirectorySource) Process(ctx context.Context) (int, error) {
+	entries, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return 0, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime().Before(entries[j].ModTime())
+	})
+
+	var (
+		processed int
+		firstErr  error
+	)
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return processed, err
+		}
+
+		if !entry.Mode().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		if err := s.processFile(ctx, entry.Name()); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			continue
+		}
+
+		processed++
+	}
+
+	return processed, firstErr
+}
+
+func (s *DirectorySource) processFile(ctx context.Context, name string) error {
+	path := filepath.Join(s.dir, name)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	reports, err := Parse(f)
+	f.Close()
+
+	if err != nil {
+		if errors.Is(err, ErrMalformedReport) {
+			return s.move(name, FailedDir)
+		}
+
+		return err
+	}
+
+	if err := s.processor.ProcessFeedback(ctx, reports); err != nil {
+		return err
+	}
+
+	return s.move(name, ProcessedDir)
+}
+
+func (s *DirectorySource) move(name, dir string) error {
+	if err := os.MkdirAll(filepath.Join(s.dir, dir), 0755); err != nil {
+		return err
+	}
+
+	return os.Rename(filepath.Join(s.dir, name), filepath.Join(s.dir, dir, name))
+}
+
+// NewHandler creates an HTTP handler accepting report messages posted as the
+// raw MIME message in the request body.
+//
+// It responds with 202 Accepted once the reports have been applied, with 413
+// Request Entity Too Large for messages larger than 10 MiB and with 422
+// Unprocessable Entity for messages that cannot be parsed.
+func NewHandler(processor Processor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxMessageSize))
+		if err != nil {
+			// Reading stops with an error once the limit has been read.
+			if len(body) >= maxMessageSize {
+				http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			} else {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			}
+
+			return
+		}
+
+		reports, err := Parse(bytes.NewReader(body))
+		if err != nil {
+			if errors.Is(err, ErrMalformedReport) {
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			} else {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			}
+
+			return
+		}
+
+		if err := processor.ProcessFeedback(r.Context(), reports); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
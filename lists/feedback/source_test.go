@@ -0,0 +1,117 @@
+package feedback
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type recordingProcessor struct {
+	reports []*Report
+}
+
+func (p *recordingProcessor) ProcessFeedback(ctx context.Context, reports []*Report) error {
+	p.reports = append(p.reports, reports...)
+
+	return nil
+}
+
+type failingProcessor struct {
+	err error
+}
+
+func (p failingProcessor) ProcessFeedback(ctx context.Context, reports []*Report) error {
+	return p.err
+}
+
+func TestDirectorySourceRunReportsErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	dsn, err := ioutil.ReadFile(filepath.Join("testdata", "dsn.eml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "dsn.eml"), dsn, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	processErr := errors.New("database unavailable")
+
+	var reported error
+
+	source := NewDirectorySource(dir, failingProcessor{err: processErr})
+	source.OnError = func(err error) {
+		reported = err
+
+		cancel()
+	}
+
+	source.Run(ctx, time.Millisecond) //nolint:errcheck
+
+	if !errors.Is(reported, processErr) {
+		t.Errorf("reported %v, want %v", reported, processErr)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "dsn.eml")); err != nil {
+		t.Errorf("failed message was not left in place: %v", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	dsn, err := ioutil.ReadFile(filepath.Join("testdata", "dsn.eml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notReport, err := ioutil.ReadFile(filepath.Join("testdata", "not-a-report.eml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A report padded past the limit must be rejected rather than parsed from
+	// its first 10 MiB.
+	oversized := append(append([]byte{}, dsn...), bytes.Repeat([]byte(" "), maxMessageSize)...)
+
+	tests := []struct {
+		name    string
+		body    []byte
+		status  int
+		reports int
+	}{
+		{name: "report", body: dsn, status: http.StatusAccepted, reports: 3},
+		{name: "not a report", body: notReport, status: http.StatusUnprocessableEntity},
+		{name: "too large", body: oversized, status: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &recordingProcessor{}
+			rec := httptest.NewRecorder()
+
+			NewHandler(processor).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/feedback", bytes.NewReader(tt.body)))
+
+			if rec.Code != tt.status {
+				t.Errorf("responded with %d, want %d", rec.Code, tt.status)
+			}
+
+			if len(processor.reports) != tt.reports {
+				t.Errorf("processed %d reports, want %d", len(processor.reports), tt.reports)
+			}
+		})
+	}
+}
@@ -0,0 +1,411 @@
+package lists
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+)
+
+// DefaultImportBatchSize is the number of rows imported per transaction when
+// ImportOptions.BatchSize is not set.
+const DefaultImportBatchSize = 500
+
+// errDryRun rolls back the transaction of a dry-run import batch.
+var errDryRun = errors.New("dry run")
+
+// ImportOutcome is the outcome of importing a CSV row.
+type ImportOutcome string
+
+// Import outcomes.
+const (
+	ImportCreated ImportOutcome = "created"
+	ImportUpdated ImportOutcome = "updated"
+	ImportSkipped ImportOutcome = "skipped"
+	ImportFailed  ImportOutcome = "failed"
+)
+
+// ImportOptions configures a subscriber import.
+type ImportOptions struct {
+	// EmailColumn is the header of the column holding email addresses.
+	EmailColumn string
+	// Fields maps column headers to subscription data field names. Columns
+	// that are not mapped are ignored.
+	Fields map[string]string
+	// Comma is the field delimiter. It defaults to a comma.
+	Comma rune
+	// BatchSize is the number of rows imported per transaction.
+	BatchSize int
+	// DryRun validates the whole file without writing anything.
+	DryRun bool
+}
+
+// ImportRow is the outcome of importing a CSV row.
+type ImportRow struct {
+	// Line is the line number of the row in the file.
+	Line         int
+	EmailAddress string
+	Outcome      ImportOutcome
+	Reason       error
+}
+
+// ImportReport reports the outcome of a subscriber import.
+type ImportReport struct {
+	Created int
+	Updated int
+	Skipped int
+	Failed  int
+	// Rows lists the rows that were skipped or failed, with the reason.
+	Rows []ImportRow
+}
+
+func (r *ImportReport) add(row ImportRow) {
+	switch row.Outcome {
+	case ImportCreated:
+		r.Created++
+	case ImportUpdated:
+		r.Updated++
+	case ImportSkipped:
+		r.Skipped++
+	case ImportFailed:
+		r.Failed++
+	}
+
+	if row.Outcome == ImportSkipped || row.Outcome == ImportFailed {
+		r.Rows = append(r.Rows, row)
+	}
+}
+
+// importRecord is a parsed CSV row awaiting import.
+type importRecord struct {
+	line         int
+	emailAddress string
+	data         domain.SubscriptionData
+}
+
+// ImportSubscribers subscribes the subscribers of a CSV file into a list.
+//
+// The file is streamed and its rows are imported in batched transactions.
+// Subscribers are matched to existing subscribers on the canonical form of
+// their email address, and addresses repeated within the file are skipped.
+// Data of existing active subscriptions is merged according to MergeRule, while
+// subscribers who are pending, have unsubscribed or have otherwise stopped
+// their subscription are skipped.
+func (u *Usecase) ImportSubscribers(ctx context.Context, orgPK, listPK uuid.UUID, r io.Reader, opts ImportOptions) (*ImportReport, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultImportBatchSize
+	}
+
+	if err := u.transaction(ctx, func(tx Transaction) error {
+		_, err := tx.Lists().GetList(ctx, orgPK, listPK)
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := importColumns(header, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{}
+	seen := map[domain.EmailAddress]int{}
+	batch := []importRecord{}
+
+	flush := func() {
+		for _, row := range u.importBatch(ctx, orgPK, listPK, batch, opts.DryRun) {
+			report.add(row)
+		}
+
+		batch = batch[:0]
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			perr, ok := err.(*csv.ParseError)
+			if !ok {
+				return report, err
+			}
+
+			report.add(ImportRow{Line: perr.StartLine, Outcome: ImportFailed, Reason: err})
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := columns.record(line, fields)
+
+		addr, err := domain.ParseEmailAddress(record.emailAddress)
+		if err != nil {
+			report.add(ImportRow{Line: line, EmailAddress: record.emailAddress, Outcome: ImportFailed, Reason: err})
+			continue
+		}
+
+		if first, ok := seen[addr.Canonical()]; ok {
+			report.add(ImportRow{
+				Line:         line,
+				EmailAddress: record.emailAddress,
+				Outcome:      ImportSkipped,
+				Reason:       fmt.Errorf("duplicate of line %d", first),
+			})
+			continue
+		}
+
+		seen[addr.Canonical()] = line
+		batch = append(batch, record)
+
+		if len(batch) >= opts.BatchSize {
+			flush()
+		}
+	}
+
+	if len(batch) > 0 {
+		flush()
+	}
+
+	return report, nil
+}
+
+// importBatch imports a batch of records in a single transaction.
+//
+// Should the transaction fail, every row that would have been written is
+// reported as failed. A dry run always rolls the transaction back.
+func (u *Usecase) importBatch(ctx context.Context, orgPK, listPK uuid.UUID, records []importRecord, dryRun bool) []ImportRow {
+	var rows []ImportRow
+
+	err := u.transaction(ctx, func(tx Transaction) error {
+		rows = []ImportRow{}
+
+		list, err := tx.Lists().GetList(ctx, orgPK, listPK)
+		if err != nil {
+			return err
+		}
+
+		org, err := tx.Organizations().GetOrganization(ctx, orgPK)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			row, err := u.importRecord(ctx, tx, *org, *list, record)
+			if err != nil {
+				return err
+			}
+
+			rows = append(rows, *row)
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+
+	if err == nil || err == errDryRun {
+		return rows
+	}
+
+	res := []ImportRow{}
+
+	for _, record := range records {
+		res = append(res, ImportRow{
+			Line:         record.line,
+			EmailAddress: record.emailAddress,
+			Outcome:      ImportFailed,
+			Reason:       err,
+		})
+	}
+
+	return res
+}
+
+// importRecord imports a single record. Domain errors are reported as the
+// reason of a failed row, while any other error aborts the batch.
+func (u *Usecase) importRecord(ctx context.Context, tx Transaction, org domain.Organization, list domain.List, record importRecord) (*ImportRow, error) {
+	row := &ImportRow{
+		Line:         record.line,
+		EmailAddress: record.emailAddress,
+	}
+
+	fail := func(reason error) (*ImportRow, error) {
+		row.Outcome = ImportFailed
+		row.Reason = reason
+
+		return row, nil
+	}
+
+	addr, err := domain.ParseEmailAddress(record.emailAddress)
+	if err != nil {
+		return fail(err)
+	}
+
+	if err := checkSuppressions(ctx, tx, org.PK, addr); err != nil {
+		var suppressed *domain.SuppressedError
+		if errors.As(err, &suppressed) {
+			return fail(err)
+		}
+
+		return nil, err
+	}
+
+	subscriber, err := tx.Subscribers().GetSubscriberByEmailAddress(ctx, org.PK, addr)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	var existing *domain.Subscription
+
+	isNew := subscriber == nil
+
+	if isNew {
+		if subscriber, err = domain.CreateSubscriber(org, addr); err != nil {
+			return fail(err)
+		}
+	} else {
+		existing, err = tx.Subscriptions().GetSubscriptionForSubscriber(ctx, org.PK, list.PK, subscriber.PK)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	if existing == nil {
+		subscription, err := domain.CreateSubscription(*subscriber, list, record.data)
+		if err != nil {
+			return fail(err)
+		}
+
+		if isNew {
+			if err := saveSubscriber(ctx, tx, subscriber, newSubscriberCreated(subscriber)); err != nil {
+				return nil, err
+			}
+		}
+
+		event, err := newSubscriberSubscribed(subscription, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := saveSubscription(ctx, tx, subscription, event); err != nil {
+			return nil, err
+		}
+
+		row.Outcome = ImportCreated
+
+		return row, nil
+	}
+
+	if existing.State != domain.SubscriptionActive {
+		row.Outcome = ImportSkipped
+		row.Reason = fmt.Errorf("subscription is %s", existing.State)
+
+		return row, nil
+	}
+
+	subscription, err := domain.ChangeSubscriptionData(*existing, list, record.data, u.MergeRule)
+	if err != nil {
+		return fail(err)
+	}
+
+	if reflect.DeepEqual(existing.Data, subscription.Data) {
+		row.Outcome = ImportSkipped
+		row.Reason = errors.New("already subscribed with the same data")
+
+		return row, nil
+	}
+
+	event, err := newSubscriptionDataChanged(subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := saveSubscription(ctx, tx, subscription, event); err != nil {
+		return nil, err
+	}
+
+	row.Outcome = ImportUpdated
+
+	return row, nil
+}
+
+// importColumnMap locates the mapped columns of a CSV header.
+type importColumnMap struct {
+	email  int
+	fields map[int]string
+}
+
+func importColumns(header []string, opts ImportOptions) (*importColumnMap, error) {
+	columns := &importColumnMap{
+		email:  -1,
+		fields: map[int]string{},
+	}
+
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+
+		if name == opts.EmailColumn {
+			columns.email = i
+		}
+
+		if field, ok := opts.Fields[name]; ok {
+			columns.fields[i] = field
+		}
+	}
+
+	if columns.email < 0 {
+		return nil, &domain.ValidationError{Message: fmt.Sprintf("email column %q not found", opts.EmailColumn)}
+	}
+
+	if len(columns.fields) != len(opts.Fields) {
+		return nil, &domain.ValidationError{Message: "mapped columns not found"}
+	}
+
+	return columns, nil
+}
+
+func (m *importColumnMap) record(line int, fields []string) importRecord {
+	record := importRecord{
+		line: line,
+		data: domain.SubscriptionData{},
+	}
+
+	if m.email < len(fields) {
+		record.emailAddress = fields[m.email]
+	}
+
+	for i, field := range m.fields {
+		if i < len(fields) && fields[i] != "" {
+			record.data[field] = fields[i]
+		}
+	}
+
+	return record
+}
@@ -0,0 +1,698 @@
+// Package memory implements the subscriber list repositories in memory, for
+// running the list commands without a database.
+package memory
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/janartodesk/domain-design/pkg/outbox"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnitOfWork runs units of work against state held in memory.
+//
+// Units of work run one at a time on a copy of the state, which replaces the
+// state once the unit of work succeeds. Versioned aggregates are saved with
+// the same optimistic concurrency checks as in a database.
+type UnitOfWork struct {
+	mu     sync.Mutex
+	state  *state
+	events lists.EventPublisher
+}
+
+// NewUnitOfWork creates an empty in-memory unit of work. Events enqueued by a
+// unit of work are published to events in order once it succeeds. A nil
+// publisher discards them.
+func NewUnitOfWork(events lists.EventPublisher) *UnitOfWork {
+	return &UnitOfWork{
+		state:  newState(),
+		events: events,
+	}
+}
+
+// Do runs fn on a copy of the state and commits the copy when fn returns nil,
+// unless the context has been cancelled by then.
+//
+// An error publishing the events is returned even though the changes have
+// been committed, like an outbox relay that has yet to deliver them.
+func (w *UnitOfWork) Do(ctx context.Context, fn func(lists.Transaction) error) error {
+	tx, err := w.commit(ctx, fn)
+	if err != nil {
+		return err
+	}
+
+	if w.events == nil {
+		return nil
+	}
+
+	for _, env := range tx.envelopes {
+		if err := w.events.Publish(ctx, env); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// commit runs fn on a copy of the state and replaces the state with the copy
+// when fn succeeds. The state is unlocked again even when fn panics.
+func (w *UnitOfWork) commit(ctx context.Context, fn func(lists.Transaction) error) (*transaction, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	tx := &transaction{
+		state: w.state.clone(),
+	}
+
+	if err := fn(tx); err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	w.state = tx.state
+
+	return tx, nil
+}
+
+// state holds every stored entity by primary key, and the enqueued events in
+// order like an outbox that keeps published messages. Stored entities and
+// events are never changed in place, so cloning the state only copies the
+// maps and the slice.
+type state struct {
+	organizations map[uuid.UUID]domain.Organization
+	lists         map[uuid.UUID]domain.List
+	subscribers   map[uuid.UUID]domain.Subscriber
+	subscriptions map[uuid.UUID]domain.Subscription
+	confirmations map[uuid.UUID]confirmation
+	suppressions  map[uuid.UUID]domain.Suppression
+	feedback      map[feedbackReport]bool
+	outbox        []*outbox.Envelope
+}
+
+// confirmation is a stored confirmation. Like in a database, only a hash of
+// its token is kept.
+type confirmation struct {
+	domain.Confirmation
+	TokenHash string
+}
+
+func newState() *state {
+	return &state{
+		organizations: map[uuid.UUID]domain.Organization{},
+		lists:         map[uuid.UUID]domain.List{},
+		subscribers:   map[uuid.UUID]domain.Subscriber{},
+		subscriptions: map[uuid.UUID]domain.Subscription{},
+		confirmations: map[uuid.UUID]confirmation{},
+		suppressions:  map[uuid.UUID]domain.Suppression{},
+		feedback:      map[feedbackReport]bool{},
+	}
+}
+
+func (s *state) clone() *state {
+	c := newState()
+
+	for k, v := range s.organizations {
+		c.organizations[k] = v
+	}
+
+	for k, v := range s.lists {
+		c.lists[k] = v
+	}
+
+	for k, v := range s.subscribers {
+		c.subscribers[k] = v
+	}
+
+	for k, v := range s.subscriptions {
+		c.subscriptions[k] = v
+	}
+
+	for k, v := range s.confirmations {
+		c.confirmations[k] = v
+	}
+
+	for k, v := range s.suppressions {
+		c.suppressions[k] = v
+	}
+
+	for k, v := range s.feedback {
+		c.feedback[k] = v
+	}
+
+	c.outbox = append(c.outbox, s.outbox...)
+
+	return c
+}
+
+// feedbackReport is a processed feedback report. Like in a database, only a
+// hash of its canonical recipient address is kept.
+type feedbackReport struct {
+	OrganizationPK uuid.UUID
+	MessageID      string
+	RecipientHash  string
+	Type           string
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// copyList copies a list, sharing nothing with the original. The schema takes
+// a round trip through JSON as it does through a database.
+func copyList(list domain.List) (domain.List, error) {
+	b, err := json.Marshal(list.Schema)
+	if err != nil {
+		return list, err
+	}
+
+	list.Schema = domain.Schema{}
+
+	if err := json.Unmarshal(b, &list.Schema); err != nil {
+		return list, err
+	}
+
+	return list, nil
+}
+
+// copySubscription copies a subscription, sharing nothing with the original.
+// The data takes a round trip through JSON as it does through a database.
+func copySubscription(subscription domain.Subscription) (domain.Subscription, error) {
+	if subscription.Data == nil {
+		return subscription, nil
+	}
+
+	b, err := json.Marshal(subscription.Data)
+	if err != nil {
+		return subscription, err
+	}
+
+	subscription.Data = nil
+
+	if err := json.Unmarshal(b, &subscription.Data); err != nil {
+		return subscription, err
+	}
+
+	return subscription, nil
+}
+
+// transaction implements every repository on a copy of the state.
+type transaction struct {
+	state     *state
+	envelopes []*outbox.Envelope
+}
+
+func (t *transaction) Organizations() lists.OrganizationRepository {
+	return t
+}
+
+func (t *transaction) Lists() lists.ListRepository {
+	return t
+}
+
+func (t *transaction) Subscribers() lists.SubscriberRepository {
+	return t
+}
+
+func (t *transaction) Subscriptions() lists.SubscriptionRepository {
+	return t
+}
+
+func (t *transaction) Confirmations() lists.ConfirmationRepository {
+	return t
+}
+
+func (t *transaction) Suppressions() lists.SuppressionRepository {
+	return t
+}
+
+func (t *transaction) Feedback() lists.FeedbackRepository {
+	return t
+}
+
+func (t *transaction) Enqueue(ctx context.Context, env *outbox.Envelope) error {
+	t.envelopes = append(t.envelopes, env)
+	t.state.outbox = append(t.state.outbox, env)
+
+	return nil
+}
+
+func (t *transaction) Redact(ctx context.Context, aggregateType string, aggregateID uuid.UUID, redact outbox.RedactFunc) error {
+	for i, env := range t.state.outbox {
+		if env.GetAggregateType() != aggregateType || uuid.FromBytesOrNil(env.GetAggregateID()) != aggregateID {
+			continue
+		}
+
+		env = proto.Clone(env).(*outbox.Envelope)
+
+		ok, err := outbox.RedactEnvelope(env, redact)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			t.state.outbox[i] = env
+		}
+	}
+
+	return nil
+}
+
+func (t *transaction) GetOrganization(ctx context.Context, pk uuid.UUID) (*domain.Organization, error) {
+	org, ok := t.state.organizations[pk]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+
+	return &org, nil
+}
+
+func (t *transaction) CreateOrganization(ctx context.Context, org *domain.Organization) error {
+	if _, ok := t.state.organizations[org.PK]; ok {
+		return domain.ErrDuplicate
+	}
+
+	t.state.organizations[org.PK] = *org
+
+	return nil
+}
+
+func (t *transaction) UpdateOrganization(ctx context.Context, org *domain.Organization) error {
+	if stored, ok := t.state.organizations[org.PK]; !ok || stored.Version != org.Version-1 {
+		return domain.ErrVersionConflict
+	}
+
+	t.state.organizations[org.PK] = *org
+
+	return nil
+}
+
+func (t *transaction) GetList(ctx context.Context, orgPK, listPK uuid.UUID) (*domain.List, error) {
+	list, ok := t.state.lists[listPK]
+	if !ok || list.OrganizationPK != orgPK {
+		return nil, domain.ErrNotFound
+	}
+
+	list, err := copyList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+func (t *transaction) SaveList(ctx context.Context, list *domain.List, env *outbox.Envelope) error {
+	stored, ok := t.state.lists[list.PK]
+
+	if list.Version == 1 {
+		if ok {
+			return domain.ErrDuplicate
+		}
+	} else if !ok || stored.OrganizationPK != list.OrganizationPK || stored.Version != list.Version-1 {
+		return domain.ErrVersionConflict
+	}
+
+	c, err := copyList(*list)
+	if err != nil {
+		return err
+	}
+
+	t.state.lists[list.PK] = c
+
+	return nil
+}
+
+func (t *transaction) DeleteList(ctx context.Context, list *domain.List, env *outbox.Envelope) error {
+	stored, ok := t.state.lists[list.PK]
+	if !ok || stored.OrganizationPK != list.OrganizationPK {
+		return domain.ErrNotFound
+	}
+
+	if stored.Version != list.Version {
+		return domain.ErrVersionConflict
+	}
+
+	delete(t.state.lists, list.PK)
+
+	return nil
+}
+
+func (t *transaction) GetSubscriber(ctx context.Context, orgPK, subscriberPK uuid.UUID) (*domain.Subscriber, error) {
+	subscriber, ok := t.state.subscribers[subscriberPK]
+	if !ok || subscriber.OrganizationPK != orgPK {
+		return nil, domain.ErrNotFound
+	}
+
+	return &subscriber, nil
+}
+
+func (t *transaction) GetSubscriberByEmailAddress(ctx context.Context, orgPK uuid.UUID, addr domain.EmailAddress) (*domain.Subscriber, error) {
+	for _, subscriber := range t.state.subscribers {
+		if subscriber.OrganizationPK == orgPK && subscriber.EmailAddress.Canonical() == addr.Canonical() {
+			return &subscriber, nil
+		}
+	}
+
+	return nil, domain.ErrNotFound
+}
+
+func (t *transaction) SaveSubscriber(ctx context.Context, subscriber *domain.Subscriber, env *outbox.Envelope) error {
+	stored, ok := t.state.subscribers[subscriber.PK]
+
+	if subscriber.Version == 1 {
+		if ok {
+			return domain.ErrDuplicate
+		}
+	} else if !ok || stored.OrganizationPK != subscriber.OrganizationPK || stored.Version != subscriber.Version-1 {
+		return domain.ErrVersionConflict
+	}
+
+	for _, other := range t.state.subscribers {
+		if other.PK != subscriber.PK &&
+			other.OrganizationPK == subscriber.OrganizationPK &&
+			other.EmailAddress.Canonical() == subscriber.EmailAddress.Canonical() {
+			return domain.ErrDuplicate
+		}
+	}
+
+	t.state.subscribers[subscriber.PK] = *subscriber
+
+	return nil
+}
+
+func (t *transaction) GetSubscription(ctx context.Context, orgPK, listPK, subscriptionPK uuid.UUID) (*domain.Subscription, error) {
+	subscription, ok := t.state.subscriptions[subscriptionPK]
+	if !ok || subscription.OrganizationPK != orgPK || subscription.ListPK != listPK {
+		return nil, domain.ErrNotFound
+	}
+
+	return t.subscription(subscription)
+}
+
+func (t *transaction) GetSubscriptionForSubscriber(ctx context.Context, orgPK, listPK, subscriberPK uuid.UUID) (*domain.Subscription, error) {
+	for _, subscription := range t.state.subscriptions {
+		if subscription.OrganizationPK == orgPK && subscription.ListPK == listPK && subscription.SubscriberPK == subscriberPK {
+			return t.subscription(subscription)
+		}
+	}
+
+	return nil, domain.ErrNotFound
+}
+
+func (t *transaction) ListSubscriptionsForSubscriber(ctx context.Context, orgPK, subscriberPK uuid.UUID) ([]*domain.Subscription, error) {
+	return t.subscriptions(func(s *domain.Subscription) bool {
+		return s.OrganizationPK == orgPK && s.SubscriberPK == subscriberPK
+	}, 0)
+}
+
+func (t *transaction) ListOutdatedSubscriptions(ctx context.Context, orgPK, listPK uuid.UUID, schemaVersion uint32, after uuid.UUID, limit uint32) ([]*domain.Subscription, error) {
+	return t.subscriptions(func(s *domain.Subscription) bool {
+		return s.OrganizationPK == orgPK &&
+			s.ListPK == listPK &&
+			s.SchemaVersion < schemaVersion &&
+			s.State != domain.SubscriptionForgotten &&
+			bytes.Compare(s.PK.Bytes(), after.Bytes()) > 0
+	}, limit)
+}
+
+func (t *transaction) ListSuppressedSubscriptions(ctx context.Context, suppression *domain.Suppression, states []domain.SubscriptionState, after uuid.UUID, limit uint32) ([]*domain.Subscription, error) {
+	return t.subscriptions(func(s *domain.Subscription) bool {
+		if !suppression.IsGlobal() && s.OrganizationPK != suppression.OrganizationPK {
+			return false
+		}
+
+		if bytes.Compare(s.PK.Bytes(), after.Bytes()) <= 0 {
+			return false
+		}
+
+		subscriber, ok := t.state.subscribers[s.SubscriberPK]
+		if !ok || !suppression.Matches(subscriber.EmailAddress) {
+			return false
+		}
+
+		for _, state := range states {
+			if s.State == state {
+				return true
+			}
+		}
+
+		return false
+	}, limit)
+}
+
+func (t *transaction) SaveSubscription(ctx context.Context, subscription *domain.Subscription, env *outbox.Envelope) error {
+	stored, ok := t.state.subscriptions[subscription.PK]
+
+	if subscription.Version == 1 {
+		if ok {
+			return domain.ErrDuplicate
+		}
+	} else if !ok ||
+		stored.OrganizationPK != subscription.OrganizationPK ||
+		stored.ListPK != subscription.ListPK ||
+		stored.Version != subscription.Version-1 {
+		return domain.ErrVersionConflict
+	}
+
+	for _, other := range t.state.subscriptions {
+		if other.PK != subscription.PK && other.ListPK == subscription.ListPK && other.SubscriberPK == subscription.SubscriberPK {
+			return domain.ErrDuplicate
+		}
+	}
+
+	c, err := copySubscription(*subscription)
+	if err != nil {
+		return err
+	}
+
+	t.state.subscriptions[subscription.PK] = c
+
+	return nil
+}
+
+func (t *transaction) DeleteSubscription(ctx context.Context, subscription *domain.Subscription, env *outbox.Envelope) error {
+	stored, ok := t.state.subscriptions[subscription.PK]
+	if !ok ||
+		stored.OrganizationPK != subscription.OrganizationPK ||
+		stored.ListPK != subscription.ListPK ||
+		stored.Version != subscription.Version-1 {
+		return domain.ErrVersionConflict
+	}
+
+	delete(t.state.subscriptions, subscription.PK)
+
+	return nil
+}
+
+// subscription returns a copy of a stored subscription.
+func (t *transaction) subscription(subscription domain.Subscription) (*domain.Subscription, error) {
+	c, err := copySubscription(subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// subscriptions returns up to limit copies of the stored subscriptions
+// matching a filter, ordered by primary key. A zero limit returns all of
+// them.
+func (t *transaction) subscriptions(filter func(*domain.Subscription) bool, limit uint32) ([]*domain.Subscription, error) {
+	res := []*domain.Subscription{}
+
+	for _, subscription := range t.state.subscriptions {
+		if !filter(&subscription) {
+			continue
+		}
+
+		c, err := t.subscription(subscription)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, c)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return bytes.Compare(res[i].PK.Bytes(), res[j].PK.Bytes()) < 0
+	})
+
+	if limit > 0 && len(res) > int(limit) {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+func (t *transaction) CreateConfirmation(ctx context.Context, c *domain.Confirmation) error {
+	if _, ok := t.state.confirmations[c.PK]; ok {
+		return domain.ErrDuplicate
+	}
+
+	stored := confirmation{
+		Confirmation: *c,
+		TokenHash:    hashToken(c.Token),
+	}
+	stored.Token = ""
+
+	t.state.confirmations[c.PK] = stored
+
+	return nil
+}
+
+func (t *transaction) DeleteConfirmation(ctx context.Context, c *domain.Confirmation) error {
+	if stored, ok := t.state.confirmations[c.PK]; !ok || stored.OrganizationPK != c.OrganizationPK {
+		return domain.ErrNotFound
+	}
+
+	delete(t.state.confirmations, c.PK)
+
+	return nil
+}
+
+func (t *transaction) DeleteConfirmationsForSubscription(ctx context.Context, orgPK, subscriptionPK uuid.UUID) error {
+	for pk, c := range t.state.confirmations {
+		if c.OrganizationPK == orgPK && c.SubscriptionPK == subscriptionPK {
+			delete(t.state.confirmations, pk)
+		}
+	}
+
+	return nil
+}
+
+func (t *transaction) GetConfirmationByToken(ctx context.Context, orgPK uuid.UUID, token string) (*domain.Confirmation, error) {
+	hash := hashToken(token)
+
+	for _, c := range t.state.confirmations {
+		if c.OrganizationPK == orgPK && c.TokenHash == hash {
+			res := c.Confirmation
+			res.Token = token
+
+			return &res, nil
+		}
+	}
+
+	return nil, domain.ErrNotFound
+}
+
+func (t *transaction) GetConfirmationForSubscription(ctx context.Context, orgPK, subscriptionPK uuid.UUID) (*domain.Confirmation, error) {
+	var res *domain.Confirmation
+
+	for _, c := range t.state.confirmations {
+		if c.OrganizationPK == orgPK && c.SubscriptionPK == subscriptionPK && (res == nil || c.ExpiresAt.After(res.ExpiresAt)) {
+			latest := c.Confirmation
+			res = &latest
+		}
+	}
+
+	if res == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	return res, nil
+}
+
+func (t *transaction) ListExpiredConfirmations(ctx context.Context, now time.Time, limit uint32) ([]*domain.Confirmation, error) {
+	res := []*domain.Confirmation{}
+
+	for _, c := range t.state.confirmations {
+		if !c.ExpiresAt.After(now) {
+			expired := c.Confirmation
+			res = append(res, &expired)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ExpiresAt.Before(res[j].ExpiresAt)
+	})
+
+	if limit > 0 && len(res) > int(limit) {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+func (t *transaction) CreateSuppression(ctx context.Context, suppression *domain.Suppression) error {
+	if _, ok := t.state.suppressions[suppression.PK]; ok {
+		return domain.ErrDuplicate
+	}
+
+	t.state.suppressions[suppression.PK] = *suppression
+
+	return nil
+}
+
+func (t *transaction) DeleteSuppression(ctx context.Context, orgPK, pk uuid.UUID) error {
+	if stored, ok := t.state.suppressions[pk]; !ok || stored.OrganizationPK != orgPK {
+		return domain.ErrNotFound
+	}
+
+	delete(t.state.suppressions, pk)
+
+	return nil
+}
+
+func (t *transaction) ListSuppressionsForEmailAddress(ctx context.Context, orgPK uuid.UUID, addr domain.EmailAddress, now time.Time) ([]*domain.Suppression, error) {
+	res := []*domain.Suppression{}
+
+	for _, suppression := range t.state.suppressions {
+		if suppression.OrganizationPK != orgPK && !suppression.IsGlobal() {
+			continue
+		}
+
+		if suppression.IsExpired(now) {
+			continue
+		}
+
+		if suppression.Matches(addr) {
+			s := suppression
+			res = append(res, &s)
+		}
+	}
+
+	return res, nil
+}
+
+func (t *transaction) RecordReport(ctx context.Context, orgPK uuid.UUID, messageID string, recipient domain.EmailAddress, reportType string) (bool, error) {
+	report := feedbackReport{
+		OrganizationPK: orgPK,
+		MessageID:      messageID,
+		RecipientHash:  hashToken(string(recipient.Canonical())),
+		Type:           reportType,
+	}
+
+	if t.state.feedback[report] {
+		return false, nil
+	}
+
+	t.state.feedback[report] = true
+
+	return true, nil
+}
+
+func (t *transaction) DeleteReportsForEmailAddress(ctx context.Context, orgPK uuid.UUID, addr domain.EmailAddress) error {
+	hash := hashToken(string(addr.Canonical()))
+
+	for report := range t.state.feedback {
+		if report.OrganizationPK == orgPK && report.RecipientHash == hash {
+			delete(t.state.feedback, report)
+		}
+	}
+
+	return nil
+}
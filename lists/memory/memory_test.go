@@ -0,0 +1,514 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/janartodesk/domain-design/lists"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/janartodesk/domain-design/lists/feedback"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestForgetSubscriberErasesPersonalData(t *testing.T) {
+	const (
+		addr = "jane.doe@example.com"
+		name = "Jane Doe"
+	)
+
+	ctx := context.Background()
+	uow := NewUnitOfWork(nil)
+	u := lists.NewUsecaseWithUnitOfWork(nil, uow)
+
+	org, err := u.CreateOrganization(ctx, "Acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := u.CreateList(ctx, org.PK, "Newsletter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := u.ChangeListSchema(ctx, org.PK, list.PK, []domain.Field{
+		{Name: "name", Type: domain.FieldString},
+	}, domain.AnyVersion); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := u.SubscribeSubscriber(ctx, org.PK, list.PK, addr, domain.SubscriptionData{"name": name}); err != nil {
+		t.Fatal(err)
+	}
+
+	var subscriber *domain.Subscriber
+
+	if err := uow.Do(ctx, func(tx lists.Transaction) error {
+		subscriber, err = tx.Subscribers().GetSubscriberByEmailAddress(ctx, org.PK, addr)
+
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := u.ProcessFeedback(ctx, []*feedback.Report{{
+		Type:           feedback.SoftBounce,
+		Recipient:      addr,
+		OrganizationPK: org.PK,
+		MessageID:      "<weekly-42@example.com>",
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(uow.state.feedback) == 0 {
+		t.Fatal("feedback report was not recorded")
+	}
+
+	if err := u.ForgetSubscriber(ctx, org.PK, subscriber.PK); err != nil {
+		t.Fatal(err)
+	}
+
+	tables := map[string]interface{}{
+		"organizations": uow.state.organizations,
+		"lists":         uow.state.lists,
+		"subscribers":   uow.state.subscribers,
+		"subscriptions": uow.state.subscriptions,
+		"confirmations": uow.state.confirmations,
+		"suppressions":  uow.state.suppressions,
+		"feedback":      uow.state.feedback,
+	}
+
+	if len(uow.state.feedback) != 0 {
+		t.Errorf("feedback holds %d reports about the forgotten subscriber", len(uow.state.feedback))
+	}
+
+	for table, rows := range tables {
+		dump := fmt.Sprintf("%+v", rows)
+
+		for _, s := range []string{addr, name} {
+			if bytes.Contains([]byte(dump), []byte(s)) {
+				t.Errorf("%s holds %q after the subscriber was forgotten", table, s)
+			}
+		}
+	}
+
+	for _, env := range uow.state.outbox {
+		b, err := proto.Marshal(env)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, s := range []string{addr, name} {
+			if bytes.Contains(b, []byte(s)) {
+				t.Errorf("outbox holds %q in a %s event after the subscriber was forgotten", s, env.GetPayload().MessageName())
+			}
+		}
+	}
+}
+
+func TestExpireConfirmationsRevertsResubscriptions(t *testing.T) {
+	const (
+		resubscribed = "jane.doe@example.com"
+		optedIn      = "john.doe@example.com"
+	)
+
+	ctx := context.Background()
+	uow := NewUnitOfWork(nil)
+	u := lists.NewUsecaseWithUnitOfWork(nil, uow)
+
+	org, err := u.CreateOrganization(ctx, "Acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := u.CreateList(ctx, org.PK, "Newsletter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subscription := func(addr domain.EmailAddress) (res *domain.Subscription) {
+		t.Helper()
+
+		if err := uow.Do(ctx, func(tx lists.Transaction) error {
+			subscriber, err := tx.Subscribers().GetSubscriberByEmailAddress(ctx, org.PK, addr)
+			if err != nil {
+				return err
+			}
+
+			res, err = tx.Subscriptions().GetSubscriptionForSubscriber(ctx, org.PK, list.PK, subscriber.PK)
+
+			return err
+		}); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			t.Fatal(err)
+		}
+
+		return res
+	}
+
+	if err := u.SubscribeSubscriber(ctx, org.PK, list.PK, resubscribed, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	active := subscription(resubscribed)
+
+	if _, err := u.Unsubscribe(ctx, org.PK, list.PK, active.PK, active.Version); err != nil {
+		t.Fatal(err)
+	}
+
+	// Opting in twice replaces the first confirmation with the second.
+	for _, addr := range []domain.EmailAddress{resubscribed, resubscribed, optedIn} {
+		if _, err := u.OptInSubscriber(ctx, org.PK, list.PK, addr, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for pk, c := range uow.state.confirmations {
+		c.ExpiresAt = time.Now().Add(-time.Minute)
+		uow.state.confirmations[pk] = c
+	}
+
+	if n, err := u.ExpireConfirmations(ctx, 10); err != nil {
+		t.Fatal(err)
+	} else if n != 2 {
+		t.Fatalf("expired %d confirmations, want 2", n)
+	}
+
+	if s := subscription(resubscribed); s == nil || s.State != domain.SubscriptionUnsubscribed {
+		t.Errorf("resubscription expired to %+v, want an unsubscribed subscription", s)
+	}
+
+	if s := subscription(optedIn); s != nil {
+		t.Errorf("opt-in expired to %+v, want no subscription", s)
+	}
+}
+
+func TestProcessFeedbackResetsSoftBouncesOnDelivery(t *testing.T) {
+	const addr = "jane.doe@example.com"
+
+	ctx := context.Background()
+	uow := NewUnitOfWork(nil)
+	u := lists.NewUsecaseWithUnitOfWork(nil, uow)
+	u.SoftBounceThreshold = 2
+
+	org, err := u.CreateOrganization(ctx, "Acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := u.CreateList(ctx, org.PK, "Newsletter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := u.SubscribeSubscriber(ctx, org.PK, list.PK, addr, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, reportType := range []feedback.ReportType{feedback.SoftBounce, feedback.Delivered, feedback.SoftBounce} {
+		if err := u.ProcessFeedback(ctx, []*feedback.Report{{
+			Type:           reportType,
+			Recipient:      addr,
+			OrganizationPK: org.PK,
+		}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := uow.Do(ctx, func(tx lists.Transaction) error {
+		subscriber, err := tx.Subscribers().GetSubscriberByEmailAddress(ctx, org.PK, addr)
+		if err != nil {
+			return err
+		}
+
+		if subscriber.SoftBounces != 1 {
+			t.Errorf("subscriber has %d soft bounces, want 1", subscriber.SoftBounces)
+		}
+
+		subscription, err := tx.Subscriptions().GetSubscriptionForSubscriber(ctx, org.PK, list.PK, subscriber.PK)
+		if err != nil {
+			return err
+		}
+
+		if subscription.State != domain.SubscriptionActive {
+			t.Errorf("subscription is %s, want %s", subscription.State, domain.SubscriptionActive)
+		}
+
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteListChecksVersion(t *testing.T) {
+	ctx := context.Background()
+	uow := NewUnitOfWork(nil)
+	u := lists.NewUsecaseWithUnitOfWork(nil, uow)
+
+	org, err := u.CreateOrganization(ctx, "Acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stale, err := u.CreateList(ctx, org.PK, "Newsletter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := u.RenameList(ctx, org.PK, stale.PK, "Weekly", stale.Version); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := uow.Do(ctx, func(tx lists.Transaction) error {
+		return tx.Lists().DeleteList(ctx, stale, nil)
+	}); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Errorf("deleting a stale list returned %v, want %v", err, domain.ErrVersionConflict)
+	}
+
+	if err := uow.Do(ctx, func(tx lists.Transaction) error {
+		_, err := tx.Lists().GetList(ctx, org.PK, stale.PK)
+
+		return err
+	}); err != nil {
+		t.Errorf("list is gone after a conflicting delete: %v", err)
+	}
+}
+
+func TestDoUnlocksAfterPanic(t *testing.T) {
+	ctx := context.Background()
+	uow := NewUnitOfWork(nil)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("Do recovered the panic of the unit of work")
+			}
+		}()
+
+		uow.Do(ctx, func(tx lists.Transaction) error { //nolint:errcheck
+			panic("unit of work failed")
+		})
+	}()
+
+	if err := uow.Do(ctx, func(tx lists.Transaction) error {
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProcessFeedbackSkipsRepeatedMessages(t *testing.T) {
+	const addr = "jane.doe@example.com"
+
+	ctx := context.Background()
+	uow := NewUnitOfWork(nil)
+	u := lists.NewUsecaseWithUnitOfWork(nil, uow)
+	u.SoftBounceThreshold = 2
+
+	org, err := u.CreateOrganization(ctx, "Acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := u.CreateList(ctx, org.PK, "Newsletter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := u.SubscribeSubscriber(ctx, org.PK, list.PK, addr, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same notification delivered twice counts as one soft bounce.
+	for i := 0; i < 2; i++ {
+		if err := u.ProcessFeedback(ctx, []*feedback.Report{{
+			Type:           feedback.SoftBounce,
+			Recipient:      addr,
+			OrganizationPK: org.PK,
+			MessageID:      "<weekly-42@example.com>",
+		}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := uow.Do(ctx, func(tx lists.Transaction) error {
+		subscriber, err := tx.Subscribers().GetSubscriberByEmailAddress(ctx, org.PK, addr)
+		if err != nil {
+			return err
+		}
+
+		if subscriber.SoftBounces != 1 {
+			t.Errorf("subscriber has %d soft bounces, want 1", subscriber.SoftBounces)
+		}
+
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSubscribeSubscriberResubscribesWithOneEvent(t *testing.T) {
+	const addr = "jane.doe@example.com"
+
+	ctx := context.Background()
+	uow := NewUnitOfWork(nil)
+	u := lists.NewUsecaseWithUnitOfWork(nil, uow)
+
+	org, err := u.CreateOrganization(ctx, "Acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := u.CreateList(ctx, org.PK, "Newsletter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := u.ChangeListSchema(ctx, org.PK, list.PK, []domain.Field{
+		{Name: "name", Type: domain.FieldString},
+	}, domain.AnyVersion); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := u.SubscribeSubscriber(ctx, org.PK, list.PK, addr, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var subscription *domain.Subscription
+
+	for _, s := range uow.state.subscriptions {
+		s := s
+		subscription = &s
+	}
+
+	if _, err := u.Unsubscribe(ctx, org.PK, list.PK, subscription.PK, subscription.Version); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := u.SubscribeSubscriber(ctx, org.PK, list.PK, addr, domain.SubscriptionData{"name": "Jane"}); err != nil {
+		t.Fatal(err)
+	}
+
+	versions := map[uint32]string{}
+
+	var last *lists.SubscriberResubscribed
+
+	for _, env := range uow.state.outbox {
+		if env.GetAggregateType() != lists.AggregateSubscription {
+			continue
+		}
+
+		name := string(env.GetPayload().MessageName())
+
+		if previous, ok := versions[env.GetAggregateVersion()]; ok {
+			t.Errorf("%s and %s share version %d", previous, name, env.GetAggregateVersion())
+		}
+
+		versions[env.GetAggregateVersion()] = name
+
+		event, err := env.GetPayload().UnmarshalNew()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		last, _ = event.(*lists.SubscriberResubscribed)
+	}
+
+	if last == nil {
+		t.Fatal("resubscription did not raise SubscriberResubscribed last")
+	}
+
+	if got := last.GetData().AsMap()["name"]; got != "Jane" {
+		t.Errorf("SubscriberResubscribed carries name %v, want Jane", got)
+	}
+}
+
+func TestListExpiredConfirmationsWithoutLimit(t *testing.T) {
+	ctx := context.Background()
+	uow := NewUnitOfWork(nil)
+	u := lists.NewUsecaseWithUnitOfWork(nil, uow)
+
+	org, err := u.CreateOrganization(ctx, "Acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := u.CreateList(ctx, org.PK, "Newsletter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, addr := range []domain.EmailAddress{"jane.doe@example.com", "john.doe@example.com"} {
+		if _, err := u.OptInSubscriber(ctx, org.PK, list.PK, addr, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for pk, c := range uow.state.confirmations {
+		c.ExpiresAt = time.Now().Add(-time.Minute)
+		uow.state.confirmations[pk] = c
+	}
+
+	for limit, want := range map[uint32]int{0: 2, 1: 1, 3: 2} {
+		if err := uow.Do(ctx, func(tx lists.Transaction) error {
+			confirmations, err := tx.Confirmations().ListExpiredConfirmations(ctx, time.Now(), limit)
+			if err != nil {
+				return err
+			}
+
+			if len(confirmations) != want {
+				t.Errorf("listed %d expired confirmations with limit %d, want %d", len(confirmations), limit, want)
+			}
+
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestQueriesWithoutDatabase(t *testing.T) {
+	ctx := context.Background()
+	u := lists.NewUsecaseWithUnitOfWork(nil, NewUnitOfWork(nil))
+
+	org, err := u.CreateOrganization(ctx, "Acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := u.CreateList(ctx, org.PK, "Newsletter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queries := map[string]func() error{
+		"GetList": func() error {
+			_, err := u.GetList(ctx, org.PK, list.PK)
+			return err
+		},
+		"GetListCounts": func() error {
+			_, err := u.GetListCounts(ctx, org.PK, list.PK)
+			return err
+		},
+		"ListDailyStats": func() error {
+			_, err := u.ListDailyStats(ctx, org.PK, list.PK, time.Now(), time.Now())
+			return err
+		},
+		"ListSuppressions": func() error {
+			_, err := u.ListSuppressions(ctx, org.PK, domain.SuppressionQuery{})
+			return err
+		},
+		"ExportList": func() error {
+			_, err := u.ExportList(ctx, org.PK, list.PK, &bytes.Buffer{}, lists.ExportOptions{Format: lists.ExportCSV})
+			return err
+		},
+	}
+
+	for name, query := range queries {
+		if err := query(); !errors.Is(err, lists.ErrNoDatabase) {
+			t.Errorf("%s returned %v, want ErrNoDatabase", name, err)
+		}
+	}
+}
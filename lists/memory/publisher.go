@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/janartodesk/domain-design/pkg/outbox"
+	"google.golang.org/protobuf/proto"
+)
+
+// RecordingPublisher records the events published to it, in order.
+type RecordingPublisher struct {
+	mu        sync.Mutex
+	envelopes []*outbox.Envelope
+}
+
+// Publish records an event.
+func (p *RecordingPublisher) Publish(ctx context.Context, env *outbox.Envelope) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.envelopes = append(p.envelopes, env)
+
+	return nil
+}
+
+// Envelopes returns the recorded events in their envelopes.
+func (p *RecordingPublisher) Envelopes() []*outbox.Envelope {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]*outbox.Envelope{}, p.envelopes...)
+}
+
+// Events returns the recorded events.
+func (p *RecordingPublisher) Events() ([]proto.Message, error) {
+	res := []proto.Message{}
+
+	for _, env := range p.Envelopes() {
+		event, err := outbox.Unwrap(env)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, event)
+	}
+
+	return res, nil
+}
+
+// Reset forgets the recorded events.
+func (p *RecordingPublisher) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.envelopes = nil
+}
@@ -0,0 +1,156 @@
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/uptrace/bun"
+)
+
+// Confirmation is a database model for a subscription confirmation.
+//
+// Only a hash of the confirmation token is stored.
+type Confirmation struct {
+	PK             uuid.UUID `bun:"pk,pk"`
+	TokenHash      string    `bun:"token_hash"`
+	OrganizationPK uuid.UUID `bun:"organization_pk"`
+	ListPK         uuid.UUID `bun:"list_pk"`
+	SubscriptionPK uuid.UUID `bun:"subscription_pk"`
+	ExpiresAt      time.Time `bun:"expires_at"`
+
+	IsResubscription bool   `bun:"is_resubscription"`
+	PreviousState    string `bun:"previous_state"`
+
+	bun.BaseModel `bun:"confirmations"`
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func (m *Confirmation) toDomain(token string) *domain.Confirmation {
+	return &domain.Confirmation{
+		PK:             m.PK,
+		Token:          token,
+		OrganizationPK: m.OrganizationPK,
+		ListPK:         m.ListPK,
+		SubscriptionPK: m.SubscriptionPK,
+		ExpiresAt:      m.ExpiresAt,
+
+		IsResubscription: m.IsResubscription,
+		PreviousState:    domain.SubscriptionState(m.PreviousState),
+	}
+}
+
+// CreateConfirmation creates a subscription confirmation.
+func CreateConfirmation(ctx context.Context, db bun.IDB, confirmation *domain.Confirmation) error {
+	if _, err := db.NewInsert().Model(&Confirmation{
+		PK:             confirmation.PK,
+		TokenHash:      hashToken(confirmation.Token),
+		OrganizationPK: confirmation.OrganizationPK,
+		ListPK:         confirmation.ListPK,
+		SubscriptionPK: confirmation.SubscriptionPK,
+		ExpiresAt:      confirmation.ExpiresAt,
+
+		IsResubscription: confirmation.IsResubscription,
+		PreviousState:    string(confirmation.PreviousState),
+	}).Exec(ctx); err != nil {
+		return queryError(err)
+	}
+
+	return nil
+}
+
+// DeleteConfirmation deletes a subscription confirmation.
+func DeleteConfirmation(ctx context.Context, db bun.IDB, confirmation *domain.Confirmation) error {
+	res, err := db.NewDelete().Model(&Confirmation{
+		PK: confirmation.PK,
+	}).WherePK().Where(
+		"organization_pk = ?",
+		confirmation.OrganizationPK,
+	).Exec(ctx)
+
+	if err != nil {
+		return queryError(err)
+	}
+
+	if c, err := res.RowsAffected(); err != nil {
+		return err
+	} else if c == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteConfirmationsForSubscription deletes every confirmation of a
+// subscription.
+func DeleteConfirmationsForSubscription(ctx context.Context, db bun.IDB, orgPK, subscriptionPK uuid.UUID) error {
+	if _, err := db.NewDelete().Model(&Confirmation{}).Where(
+		"organization_pk = ? AND subscription_pk = ?",
+		orgPK,
+		subscriptionPK,
+	).Exec(ctx); err != nil {
+		return queryError(err)
+	}
+
+	return nil
+}
+
+// GetConfirmationByToken returns a subscription confirmation by its token.
+func GetConfirmationByToken(ctx context.Context, db bun.IDB, orgPK uuid.UUID, token string) (*domain.Confirmation, error) {
+	model := Confirmation{}
+
+	if err := db.NewSelect().Model(&model).Where(
+		"token_hash = ? AND organization_pk = ?",
+		hashToken(token),
+		orgPK,
+	).Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	return model.toDomain(token), nil
+}
+
+// GetConfirmationForSubscription returns the latest confirmation of a
+// subscription, without its token.
+func GetConfirmationForSubscription(ctx context.Context, db bun.IDB, orgPK, subscriptionPK uuid.UUID) (*domain.Confirmation, error) {
+	model := Confirmation{}
+
+	if err := db.NewSelect().Model(&model).Where(
+		"organization_pk = ? AND subscription_pk = ?",
+		orgPK,
+		subscriptionPK,
+	).Order("expires_at DESC").Limit(1).Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	return model.toDomain(""), nil
+}
+
+// ListExpiredConfirmations returns confirmations that have expired at the given
+// time, across all organizations.
+func ListExpiredConfirmations(ctx context.Context, db bun.IDB, now time.Time, limit uint32) ([]*domain.Confirmation, error) {
+	model := []Confirmation{}
+
+	if err := db.NewSelect().Model(&model).Where(
+		"expires_at <= ?",
+		now,
+	).Order("expires_at ASC").Limit(int(limit)).Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	res := []*domain.Confirmation{}
+
+	for _, confirmation := range model {
+		res = append(res, confirmation.toDomain(""))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/janartodesk/domain-design/pkg/db"
+)
+
+// queryError translates the errors of the database into the errors of the
+// domain. Missing rows are domain.ErrNotFound and unique constraint violations
+// are domain.ErrDuplicate.
+func queryError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+
+	if db.IsUniqueViolation(err) {
+		return domain.ErrDuplicate
+	}
+
+	return err
+}
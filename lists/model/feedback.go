@@ -0,0 +1,69 @@
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/uptrace/bun"
+)
+
+// FeedbackReport is a database model for a processed feedback report.
+//
+// Only a hash of the canonical recipient address is stored.
+type FeedbackReport struct {
+	OrganizationPK uuid.UUID `bun:"organization_pk,pk"`
+	MessageID      string    `bun:"message_id,pk"`
+	RecipientHash  string    `bun:"recipient_hash,pk"`
+	Type           string    `bun:"type,pk"`
+	ProcessedAt    time.Time `bun:"processed_at"`
+
+	bun.BaseModel `bun:"feedback_reports"`
+}
+
+// HashRecipient returns the hash a feedback report recipient is stored as.
+func HashRecipient(addr domain.EmailAddress) string {
+	sum := sha256.Sum256([]byte(addr.Canonical()))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// RecordFeedbackReport records a processed feedback report and reports whether
+// it had not been recorded before.
+func RecordFeedbackReport(ctx context.Context, db bun.IDB, orgPK uuid.UUID, messageID string, recipient domain.EmailAddress, reportType string) (bool, error) {
+	res, err := db.NewInsert().Model(&FeedbackReport{
+		OrganizationPK: orgPK,
+		MessageID:      messageID,
+		RecipientHash:  HashRecipient(recipient),
+		Type:           reportType,
+		ProcessedAt:    time.Now(),
+	}).On("CONFLICT DO NOTHING").Exec(ctx)
+
+	if err != nil {
+		return false, queryError(err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+// DeleteFeedbackReportsForEmailAddress deletes the processed feedback reports
+// about an email address.
+func DeleteFeedbackReportsForEmailAddress(ctx context.Context, db bun.IDB, orgPK uuid.UUID, addr domain.EmailAddress) error {
+	if _, err := db.NewDelete().Model(&FeedbackReport{}).Where(
+		"organization_pk = ? AND recipient_hash = ?",
+		orgPK,
+		HashRecipient(addr),
+	).Exec(ctx); err != nil {
+		return queryError(err)
+	}
+
+	return nil
+}
@@ -2,7 +2,7 @@ package model
 
 import (
 	"context"
-	"errors"
+	"fmt"
 
 	"github.com/gofrs/uuid"
 	"github.com/janartodesk/domain-design/lists/domain"
@@ -11,103 +11,155 @@ import (
 
 // List is a database model for a subscriber list.
 type List struct {
-	PK      uuid.UUID `bun:"pk,pk"`
-	Title   string    `bun:"title"`
-	Version uint32    `bun:"version"`
+	PK             uuid.UUID     `bun:"pk,pk"`
+	OrganizationPK uuid.UUID     `bun:"organization_pk"`
+	Title          string        `bun:"title"`
+	Schema         domain.Schema `bun:"schema"`
+	Version        uint32        `bun:"version"`
 
 	bun.BaseModel `bun:"lists"`
 }
 
+func newList(list *domain.List) *List {
+	return &List{
+		PK:             list.PK,
+		OrganizationPK: list.OrganizationPK,
+		Title:          list.Title,
+		Schema:         list.Schema,
+		Version:        list.Version,
+	}
+}
+
+func (m *List) toDomain() *domain.List {
+	return &domain.List{
+		PK:             m.PK,
+		OrganizationPK: m.OrganizationPK,
+		Title:          m.Title,
+		Schema:         m.Schema,
+		Version:        m.Version,
+	}
+}
+
 // CreateList creates a subscriber list.
-func CreateList(db bun.IDB, list *domain.List) error {
-	if _, err := db.NewInsert().Model(&List{
-		PK:      list.PK,
-		Title:   list.Title,
-		Version: list.Version,
-	}).Exec(context.Background()); err != nil {
-		return err
+func CreateList(ctx context.Context, db bun.IDB, list *domain.List) error {
+	if _, err := db.NewInsert().Model(newList(list)).Exec(ctx); err != nil {
+		return queryError(err)
 	}
 
 	return nil
 }
 
-// DeleteList deletes a subscriber list.
-func DeleteList(db bun.IDB, pk uuid.UUID) error {
-	res, err := db.NewDelete().Model(&List{
-		PK: pk,
-	}).WherePK().Exec(context.Background())
+// DeleteList deletes a subscriber list at its last saved version.
+func DeleteList(ctx context.Context, db bun.IDB, list *domain.List) error {
+	res, err := db.NewDelete().Model(&List{}).Where(
+		"pk = ? AND organization_pk = ? AND version = ?",
+		list.PK,
+		list.OrganizationPK,
+		list.Version,
+	).Exec(ctx)
 
 	if err != nil {
-		return err
+		return queryError(err)
 	}
 
 	if c, err := res.RowsAffected(); err != nil {
 		return err
 	} else if c == 0 {
-		return errors.New("precondition failed")
+		return domain.ErrVersionConflict
 	}
 
 	return nil
 }
 
 // UpdateList updates a subscriber list.
-func UpdateList(db bun.IDB, list *domain.List) error {
-	res, err := db.NewUpdate().Model(&List{
-		PK:      list.PK,
-		Title:   list.Title,
-		Version: list.Version,
-	}).Where(
-		"pk = ? AND version = ?",
+func UpdateList(ctx context.Context, db bun.IDB, list *domain.List) error {
+	res, err := db.NewUpdate().Model(newList(list)).Where(
+		"pk = ? AND organization_pk = ? AND version = ?",
 		list.PK,
+		list.OrganizationPK,
 		list.Version-1,
-	).Exec(context.Background())
+	).Exec(ctx)
 
 	if err != nil {
-		return err
+		return queryError(err)
 	}
 
 	if c, err := res.RowsAffected(); err != nil {
 		return err
 	} else if c == 0 {
-		return errors.New("precondition failed")
+		return domain.ErrVersionConflict
 	}
 
 	return nil
 }
 
 // GetList returns a subscriber list.
-func GetList(db bun.IDB, pk uuid.UUID) (*domain.List, error) {
+func GetList(ctx context.Context, db bun.IDB, orgPK, pk uuid.UUID) (*domain.List, error) {
 	model := List{
 		PK: pk,
 	}
 
-	if err := db.NewSelect().Model(&model).WherePK().Scan(context.Background()); err != nil {
-		return nil, err
+	if err := db.NewSelect().Model(&model).WherePK().Where(
+		"organization_pk = ?",
+		orgPK,
+	).Scan(ctx); err != nil {
+		return nil, queryError(err)
 	}
 
-	return &domain.List{
-		PK:      model.PK,
-		Title:   model.Title,
-		Version: model.Version,
-	}, nil
+	return model.toDomain(), nil
 }
 
-// ListLists returns a list of subscriber lists.
-func ListLists(db bun.IDB, offset, limit uint32) ([]*domain.List, error) {
-	model := []List{}
+var listSortColumns = map[domain.ListSortField]string{
+	"":                     "title",
+	domain.ListSortByTitle: "title",
+}
 
-	if err := db.NewSelect().Model(&model).Scan(context.Background()); err != nil {
+// ListLists returns a page of subscriber lists.
+func ListLists(ctx context.Context, db bun.IDB, orgPK uuid.UUID, query domain.ListQuery) (*domain.ListPage, error) {
+	column, ok := listSortColumns[query.SortBy]
+	if !ok {
+		return nil, &domain.ValidationError{Message: fmt.Sprintf("unsupported sort field %q", query.SortBy)}
+	}
+
+	page, err := newPageQuery(column, query.Direction, query.PageRequest)
+	if err != nil {
 		return nil, err
 	}
 
-	res := []*domain.List{}
+	filter := func(q *bun.SelectQuery) *bun.SelectQuery {
+		q = q.Where("organization_pk = ?", orgPK)
+
+		if query.TitleContains != "" {
+			q = q.Where("title ILIKE ?", containsPattern(query.TitleContains))
+		}
+
+		return q
+	}
+
+	model := []List{}
+
+	if err := db.NewSelect().Model(&model).Apply(filter).Apply(page.apply).Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	res := &domain.ListPage{
+		Lists: []*domain.List{},
+	}
+
+	if page.hasNext(len(model)) {
+		model = model[:page.limit]
+		last := model[len(model)-1]
+		res.NextCursor = page.nextCursor(last.Title, last.PK)
+	}
 
 	for _, list := range model {
-		res = append(res, &domain.List{
-			PK:      list.PK,
-			Title:   list.Title,
-			Version: list.Version,
-		})
+		res.Lists = append(res.Lists, list.toDomain())
+	}
+
+	if query.WithTotal {
+		if res.Total, err = countTotal(ctx, db.NewSelect().Model((*List)(nil)).Apply(filter)); err != nil {
+			return nil, err
+		}
 	}
 
 	return res, nil
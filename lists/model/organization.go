@@ -0,0 +1,73 @@
+package model
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/uptrace/bun"
+)
+
+// Organization is a database model for an organization.
+type Organization struct {
+	PK      uuid.UUID `bun:"pk,pk"`
+	Name    string    `bun:"name"`
+	Version uint32    `bun:"version"`
+
+	bun.BaseModel `bun:"organizations"`
+}
+
+// CreateOrganization creates an organization.
+func CreateOrganization(ctx context.Context, db bun.IDB, org *domain.Organization) error {
+	if _, err := db.NewInsert().Model(&Organization{
+		PK:      org.PK,
+		Name:    org.Name,
+		Version: org.Version,
+	}).Exec(ctx); err != nil {
+		return queryError(err)
+	}
+
+	return nil
+}
+
+// UpdateOrganization updates an organization.
+func UpdateOrganization(ctx context.Context, db bun.IDB, org *domain.Organization) error {
+	res, err := db.NewUpdate().Model(&Organization{
+		PK:      org.PK,
+		Name:    org.Name,
+		Version: org.Version,
+	}).Where(
+		"pk = ? AND version = ?",
+		org.PK,
+		org.Version-1,
+	).Exec(ctx)
+
+	if err != nil {
+		return queryError(err)
+	}
+
+	if c, err := res.RowsAffected(); err != nil {
+		return err
+	} else if c == 0 {
+		return domain.ErrVersionConflict
+	}
+
+	return nil
+}
+
+// GetOrganization returns an organization.
+func GetOrganization(ctx context.Context, db bun.IDB, pk uuid.UUID) (*domain.Organization, error) {
+	model := Organization{
+		PK: pk,
+	}
+
+	if err := db.NewSelect().Model(&model).WherePK().Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	return &domain.Organization{
+		PK:      model.PK,
+		Name:    model.Name,
+		Version: model.Version,
+	}, nil
+}
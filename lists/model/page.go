@@ -0,0 +1,112 @@
+package model
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/uptrace/bun"
+)
+
+// cursor is the decoded form of an opaque page cursor. It holds the sort key
+// of the last row on a page.
+type cursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	PK    uuid.UUID `json:"k"`
+}
+
+// pageQuery applies keyset pagination ordered by a column and the primary key
+// as a tie breaker, so that the order is stable across pages.
+type pageQuery struct {
+	column    string
+	direction domain.SortDirection
+	cursor    *cursor
+	limit     uint32
+}
+
+func newPageQuery(column string, direction domain.SortDirection, req domain.PageRequest) (*pageQuery, error) {
+	if direction == "" {
+		direction = domain.SortAscending
+	}
+
+	p := &pageQuery{
+		column:    column,
+		direction: direction,
+		limit:     req.PageLimit(),
+	}
+
+	if req.Cursor == "" {
+		return p, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(req.Cursor)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	c := &cursor{}
+
+	if err := json.Unmarshal(b, c); err != nil || c.Sort != p.sortKey() {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	p.cursor = c
+
+	return p, nil
+}
+
+func (p *pageQuery) sortKey() string {
+	return p.column + ":" + string(p.direction)
+}
+
+func (p *pageQuery) apply(q *bun.SelectQuery) *bun.SelectQuery {
+	op, order := ">", "ASC"
+	if p.direction == domain.SortDescending {
+		op, order = "<", "DESC"
+	}
+
+	if p.cursor != nil {
+		q = q.Where(fmt.Sprintf("(%s, pk) %s (?, ?)", p.column, op), p.cursor.Value, p.cursor.PK)
+	}
+
+	return q.OrderExpr(fmt.Sprintf("%s %s, pk %s", p.column, order, order)).Limit(int(p.limit) + 1)
+}
+
+// hasNext reports whether more rows follow the page, given the number of rows
+// fetched with the page query.
+func (p *pageQuery) hasNext(n int) bool {
+	return n > int(p.limit)
+}
+
+func (p *pageQuery) nextCursor(value string, pk uuid.UUID) string {
+	b, _ := json.Marshal(&cursor{
+		Sort:  p.sortKey(),
+		Value: value,
+		PK:    pk,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func countTotal(ctx context.Context, q *bun.SelectQuery) (*uint64, error) {
+	n, err := q.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	total := uint64(n)
+
+	return &total, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns a LIKE pattern matching values containing s.
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
@@ -0,0 +1,210 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/uptrace/bun"
+)
+
+// StatsSubscription is a database model for the state of a subscription as
+// last seen by the statistics projection.
+type StatsSubscription struct {
+	PK             uuid.UUID `bun:"pk,pk"`
+	OrganizationPK uuid.UUID `bun:"organization_pk"`
+	ListPK         uuid.UUID `bun:"list_pk"`
+	State          string    `bun:"state"`
+
+	bun.BaseModel `bun:"stats_subscriptions"`
+}
+
+// ListCounts is a database model for the subscription counts of a list.
+type ListCounts struct {
+	ListPK         uuid.UUID `bun:"list_pk,pk"`
+	OrganizationPK uuid.UUID `bun:"organization_pk"`
+	Active         int64     `bun:"active"`
+	Pending        int64     `bun:"pending"`
+	Cancelled      int64     `bun:"cancelled"`
+
+	bun.BaseModel `bun:"list_counts"`
+}
+
+// DailyListStats is a database model for the daily statistics of a list.
+type DailyListStats struct {
+	ListPK         uuid.UUID `bun:"list_pk,pk"`
+	Day            time.Time `bun:"day,pk"`
+	OrganizationPK uuid.UUID `bun:"organization_pk"`
+	OptIns         int64     `bun:"opt_ins"`
+	OptOuts        int64     `bun:"opt_outs"`
+
+	bun.BaseModel `bun:"list_daily_stats"`
+}
+
+// GetStatsSubscription returns the state of a subscription as last seen by
+// the statistics projection. Only the keys and state of the subscription are
+// set.
+func GetStatsSubscription(ctx context.Context, db bun.IDB, pk uuid.UUID) (*domain.Subscription, error) {
+	model := StatsSubscription{
+		PK: pk,
+	}
+
+	if err := db.NewSelect().Model(&model).WherePK().Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	return &domain.Subscription{
+		PK:             model.PK,
+		OrganizationPK: model.OrganizationPK,
+		ListPK:         model.ListPK,
+		State:          domain.SubscriptionState(model.State),
+	}, nil
+}
+
+// SaveStatsSubscription records the state of a subscription seen by the
+// statistics projection.
+func SaveStatsSubscription(ctx context.Context, db bun.IDB, subscription *domain.Subscription) error {
+	if _, err := db.NewInsert().Model(&StatsSubscription{
+		PK:             subscription.PK,
+		OrganizationPK: subscription.OrganizationPK,
+		ListPK:         subscription.ListPK,
+		State:          string(subscription.State),
+	}).On("CONFLICT (pk) DO UPDATE").
+		Set("state = EXCLUDED.state").
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteStatsSubscription forgets a subscription seen by the statistics
+// projection.
+func DeleteStatsSubscription(ctx context.Context, db bun.IDB, pk uuid.UUID) error {
+	if _, err := db.NewDelete().Model(&StatsSubscription{
+		PK: pk,
+	}).WherePK().Exec(ctx); err != nil {
+		return queryError(err)
+	}
+
+	return nil
+}
+
+// AddListCounts adds to the subscription counts of a list.
+func AddListCounts(ctx context.Context, db bun.IDB, orgPK, listPK uuid.UUID, active, pending, cancelled int64) error {
+	if _, err := db.NewInsert().Model(&ListCounts{
+		ListPK:         listPK,
+		OrganizationPK: orgPK,
+		Active:         active,
+		Pending:        pending,
+		Cancelled:      cancelled,
+	}).On("CONFLICT (list_pk) DO UPDATE").
+		Set("active = ?TableAlias.active + EXCLUDED.active").
+		Set("pending = ?TableAlias.pending + EXCLUDED.pending").
+		Set("cancelled = ?TableAlias.cancelled + EXCLUDED.cancelled").
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddDailyListStats adds to the statistics of a list on a day.
+func AddDailyListStats(ctx context.Context, db bun.IDB, orgPK, listPK uuid.UUID, day time.Time, optIns, optOuts int64) error {
+	if _, err := db.NewInsert().Model(&DailyListStats{
+		ListPK:         listPK,
+		Day:            domain.StatsDay(day),
+		OrganizationPK: orgPK,
+		OptIns:         optIns,
+		OptOuts:        optOuts,
+	}).On("CONFLICT (list_pk, day) DO UPDATE").
+		Set("opt_ins = ?TableAlias.opt_ins + EXCLUDED.opt_ins").
+		Set("opt_outs = ?TableAlias.opt_outs + EXCLUDED.opt_outs").
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteListStats deletes the statistics of a list.
+func DeleteListStats(ctx context.Context, db bun.IDB, listPK uuid.UUID) error {
+	for _, m := range []interface{}{
+		(*StatsSubscription)(nil),
+		(*ListCounts)(nil),
+		(*DailyListStats)(nil),
+	} {
+		if _, err := db.NewDelete().Model(m).Where("list_pk = ?", listPK).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ResetStats deletes the statistics of every list.
+func ResetStats(ctx context.Context, db bun.IDB) error {
+	for _, m := range []interface{}{
+		(*StatsSubscription)(nil),
+		(*ListCounts)(nil),
+		(*DailyListStats)(nil),
+	} {
+		if _, err := db.NewDelete().Model(m).Where("TRUE").Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetListCounts returns the subscription counts of a list. Lists without
+// subscriptions have no counts.
+func GetListCounts(ctx context.Context, db bun.IDB, orgPK, listPK uuid.UUID) (*domain.ListCounts, error) {
+	model := ListCounts{
+		ListPK: listPK,
+	}
+
+	if err := db.NewSelect().Model(&model).WherePK().Where(
+		"organization_pk = ?",
+		orgPK,
+	).Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	return &domain.ListCounts{
+		ListPK:    model.ListPK,
+		Active:    uint64(model.Active),
+		Pending:   uint64(model.Pending),
+		Cancelled: uint64(model.Cancelled),
+	}, nil
+}
+
+// ListDailyListStats returns the statistics of a list for the days from from
+// through to, skipping days without changes.
+func ListDailyListStats(ctx context.Context, db bun.IDB, orgPK, listPK uuid.UUID, from, to time.Time) ([]*domain.DailyListStats, error) {
+	model := []DailyListStats{}
+
+	if err := db.NewSelect().Model(&model).Where(
+		"list_pk = ? AND organization_pk = ? AND day BETWEEN ? AND ?",
+		listPK,
+		orgPK,
+		domain.StatsDay(from),
+		domain.StatsDay(to),
+	).Order("day ASC").Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	res := []*domain.DailyListStats{}
+
+	for _, m := range model {
+		res = append(res, &domain.DailyListStats{
+			ListPK:  m.ListPK,
+			Day:     m.Day,
+			OptIns:  uint64(m.OptIns),
+			OptOuts: uint64(m.OptOuts),
+		})
+	}
+
+	return res, nil
+}
@@ -2,7 +2,7 @@ package model
 
 import (
 	"context"
-	"errors"
+	"fmt"
 
 	"github.com/gofrs/uuid"
 	"github.com/janartodesk/domain-design/lists/domain"
@@ -11,102 +11,147 @@ import (
 
 // Subscriber is a database model for a list subscriber.
 type Subscriber struct {
-	PK           uuid.UUID `bun:"pk,pk"`
-	EmailAddress string    `bun:"email"`
-	Version      uint32    `bun:"version"`
+	PK             uuid.UUID `bun:"pk,pk"`
+	OrganizationPK uuid.UUID `bun:"organization_pk"`
+	EmailAddress   string    `bun:"email"`
+	CanonicalEmail string    `bun:"canonical_email"`
+	SoftBounces    uint32    `bun:"soft_bounces"`
+	Version        uint32    `bun:"version"`
 
 	bun.BaseModel `bun:"subscribers"`
 }
 
+func newSubscriber(subscriber *domain.Subscriber) *Subscriber {
+	return &Subscriber{
+		PK:             subscriber.PK,
+		OrganizationPK: subscriber.OrganizationPK,
+		EmailAddress:   string(subscriber.EmailAddress),
+		CanonicalEmail: string(subscriber.EmailAddress.Canonical()),
+		SoftBounces:    subscriber.SoftBounces,
+		Version:        subscriber.Version,
+	}
+}
+
+func (m *Subscriber) toDomain() *domain.Subscriber {
+	return &domain.Subscriber{
+		PK:             m.PK,
+		OrganizationPK: m.OrganizationPK,
+		EmailAddress:   domain.EmailAddress(m.EmailAddress),
+		SoftBounces:    m.SoftBounces,
+		Version:        m.Version,
+	}
+}
+
 // CreateSubscriber creates a subscriber.
-func CreateSubscriber(db bun.IDB, subscriber *domain.Subscriber) error {
-	if _, err := db.NewInsert().Model(&Subscriber{
-		PK:           subscriber.PK,
-		EmailAddress: string(subscriber.EmailAddress),
-		Version:      subscriber.Version,
-	}).Exec(context.Background()); err != nil {
-		return err
+func CreateSubscriber(ctx context.Context, db bun.IDB, subscriber *domain.Subscriber) error {
+	if _, err := db.NewInsert().Model(newSubscriber(subscriber)).Exec(ctx); err != nil {
+		return queryError(err)
 	}
 
 	return nil
 }
 
 // UpdateSubscriber updates a subscriber.
-func UpdateSubscriber(db bun.IDB, subscriber *domain.Subscriber) error {
-	res, err := db.NewUpdate().Model(&Subscriber{
-		PK:           subscriber.PK,
-		EmailAddress: string(subscriber.EmailAddress),
-		Version:      subscriber.Version,
-	}).Where(
-		"pk = ? AND version = ?",
+func UpdateSubscriber(ctx context.Context, db bun.IDB, subscriber *domain.Subscriber) error {
+	res, err := db.NewUpdate().Model(newSubscriber(subscriber)).Where(
+		"pk = ? AND organization_pk = ? AND version = ?",
 		subscriber.PK,
+		subscriber.OrganizationPK,
 		subscriber.Version-1,
-	).Exec(context.Background())
+	).Exec(ctx)
 
 	if err != nil {
-		return err
+		return queryError(err)
 	}
 
 	if c, err := res.RowsAffected(); err != nil {
 		return err
 	} else if c == 0 {
-		return errors.New("precondition failed")
+		return domain.ErrVersionConflict
 	}
 
 	return nil
 }
 
 // GetSubscriber returns a subscriber.
-func GetSubscriber(db bun.IDB, pk uuid.UUID) (*domain.Subscriber, error) {
+func GetSubscriber(ctx context.Context, db bun.IDB, orgPK, pk uuid.UUID) (*domain.Subscriber, error) {
 	model := Subscriber{
 		PK: pk,
 	}
 
-	if err := db.NewSelect().Model(&model).WherePK().Scan(context.Background()); err != nil {
-		return nil, err
+	if err := db.NewSelect().Model(&model).WherePK().Where(
+		"organization_pk = ?",
+		orgPK,
+	).Scan(ctx); err != nil {
+		return nil, queryError(err)
 	}
 
-	return &domain.Subscriber{
-		PK:           model.PK,
-		EmailAddress: domain.EmailAddress(model.EmailAddress),
-		Version:      model.Version,
-	}, nil
+	return model.toDomain(), nil
 }
 
 // GetSubscriberByEmailAddress returns a subscriber by their email address.
-func GetSubscriberByEmailAddress(db bun.IDB, addr domain.EmailAddress) (*domain.Subscriber, error) {
+//
+// Addresses are matched on their canonical form, so addresses delivered to the
+// same mailbox match the same subscriber.
+func GetSubscriberByEmailAddress(ctx context.Context, db bun.IDB, orgPK uuid.UUID, addr domain.EmailAddress) (*domain.Subscriber, error) {
 	model := Subscriber{}
 
 	if err := db.NewSelect().Model(&model).Where(
-		"email_addr = ?",
-		addr,
-	).Scan(context.Background()); err != nil {
-		return nil, err
+		"organization_pk = ? AND canonical_email = ?",
+		orgPK,
+		addr.Canonical(),
+	).Scan(ctx); err != nil {
+		return nil, queryError(err)
 	}
 
-	return &domain.Subscriber{
-		PK:           model.PK,
-		EmailAddress: domain.EmailAddress(model.EmailAddress),
-		Version:      model.Version,
-	}, nil
+	return model.toDomain(), nil
 }
 
-// ListSubscribers returns a list of subscribers.
-func ListSubscribers(db bun.IDB, offset, limit uint32) ([]*domain.Subscriber, error) {
-	model := []Subscriber{}
+var subscriberSortColumns = map[domain.SubscriberSortField]string{
+	"":                                  "email",
+	domain.SubscriberSortByEmailAddress: "email",
+}
 
-	if err := db.NewSelect().Model(&model).Scan(context.Background()); err != nil {
+// ListSubscribers returns a page of subscribers.
+func ListSubscribers(ctx context.Context, db bun.IDB, orgPK uuid.UUID, query domain.SubscriberQuery) (*domain.SubscriberPage, error) {
+	column, ok := subscriberSortColumns[query.SortBy]
+	if !ok {
+		return nil, &domain.ValidationError{Message: fmt.Sprintf("unsupported sort field %q", query.SortBy)}
+	}
+
+	page, err := newPageQuery(column, query.Direction, query.PageRequest)
+	if err != nil {
 		return nil, err
 	}
 
-	res := []*domain.Subscriber{}
+	filter := func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("organization_pk = ?", orgPK)
+	}
+
+	model := []Subscriber{}
+
+	if err := db.NewSelect().Model(&model).Apply(filter).Apply(page.apply).Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	res := &domain.SubscriberPage{
+		Subscribers: []*domain.Subscriber{},
+	}
+
+	if page.hasNext(len(model)) {
+		model = model[:page.limit]
+		last := model[len(model)-1]
+		res.NextCursor = page.nextCursor(last.EmailAddress, last.PK)
+	}
 
 	for _, subscriber := range model {
-		res = append(res, &domain.Subscriber{
-			PK:           subscriber.PK,
-			EmailAddress: domain.EmailAddress(subscriber.EmailAddress),
-			Version:      subscriber.Version,
-		})
+		res.Subscribers = append(res.Subscribers, subscriber.toDomain())
+	}
+
+	if query.WithTotal {
+		if res.Total, err = countTotal(ctx, db.NewSelect().Model((*Subscriber)(nil)).Apply(filter)); err != nil {
+			return nil, err
+		}
 	}
 
 	return res, nil
@@ -3,6 +3,8 @@ package model
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/janartodesk/domain-design/lists/domain"
@@ -11,110 +13,354 @@ import (
 
 // Subscription is a database model for a list subscription.
 type Subscription struct {
-	PK           uuid.UUID              `bun:"pk,pk"`
-	ListPK       uuid.UUID              `bun:"list_pk,pk"`
-	SubscriberPK uuid.UUID              `bun:"subscriber_pk"`
-	EmailAddress string                 `bun:"email"`
-	Data         map[string]interface{} `bun:"data"`
-	Version      uint32                 `bun:"version"`
+	PK             uuid.UUID              `bun:"pk,pk"`
+	ListPK         uuid.UUID              `bun:"list_pk,pk"`
+	OrganizationPK uuid.UUID              `bun:"organization_pk"`
+	SubscriberPK   uuid.UUID              `bun:"subscriber_pk"`
+	EmailAddress   string                 `bun:"email"`
+	Data           map[string]interface{} `bun:"data"`
+	SchemaVersion  uint32                 `bun:"schema_version"`
+	State          string                 `bun:"state"`
+	StateChangedAt time.Time              `bun:"state_changed_at"`
+	Version        uint32                 `bun:"version"`
 
 	bun.BaseModel `bun:"subscriptions"`
 }
 
+func newSubscription(subscription *domain.Subscription) *Subscription {
+	return &Subscription{
+		PK:             subscription.PK,
+		ListPK:         subscription.ListPK,
+		OrganizationPK: subscription.OrganizationPK,
+		SubscriberPK:   subscription.SubscriberPK,
+		EmailAddress:   string(subscription.EmailAddress),
+		Data:           subscription.Data,
+		SchemaVersion:  subscription.SchemaVersion,
+		State:          string(subscription.State),
+		StateChangedAt: subscription.StateChangedAt,
+		Version:        subscription.Version,
+	}
+}
+
+func (m *Subscription) toDomain() *domain.Subscription {
+	return &domain.Subscription{
+		PK:             m.PK,
+		OrganizationPK: m.OrganizationPK,
+		SubscriberPK:   m.SubscriberPK,
+		ListPK:         m.ListPK,
+		EmailAddress:   domain.EmailAddress(m.EmailAddress),
+		Data:           m.Data,
+		SchemaVersion:  m.SchemaVersion,
+		State:          domain.SubscriptionState(m.State),
+		StateChangedAt: m.StateChangedAt,
+		Version:        m.Version,
+	}
+}
+
 // CreateSubscription creates a subscription.
-func CreateSubscription(db bun.IDB, subscription *domain.Subscription) error {
-	if _, err := db.NewInsert().Model(&Subscription{
-		PK:           subscription.PK,
-		EmailAddress: string(subscription.EmailAddress),
-		Version:      subscription.Version,
-	}).Exec(context.Background()); err != nil {
-		return err
+func CreateSubscription(ctx context.Context, db bun.IDB, subscription *domain.Subscription) error {
+	if _, err := db.NewInsert().Model(newSubscription(subscription)).Exec(ctx); err != nil {
+		return queryError(err)
 	}
 
 	return nil
 }
 
 // UpdateSubscription updates a subscription.
-func UpdateSubscription(db bun.IDB, subscription *domain.Subscription) error {
-	res, err := db.NewUpdate().Model(&Subscription{
-		PK:           subscription.PK,
-		EmailAddress: string(subscription.EmailAddress),
-		Version:      subscription.Version,
-	}).Where(
-		"pk = ? AND version = ?",
+func UpdateSubscription(ctx context.Context, db bun.IDB, subscription *domain.Subscription) error {
+	res, err := db.NewUpdate().Model(newSubscription(subscription)).Where(
+		"pk = ? AND list_pk = ? AND organization_pk = ? AND version = ?",
 		subscription.PK,
+		subscription.ListPK,
+		subscription.OrganizationPK,
 		subscription.Version-1,
-	).Exec(context.Background())
+	).Exec(ctx)
 
 	if err != nil {
+		return queryError(err)
+	}
+
+	if c, err := res.RowsAffected(); err != nil {
 		return err
+	} else if c == 0 {
+		return domain.ErrVersionConflict
+	}
+
+	return nil
+}
+
+// DeleteSubscription deletes a subscription.
+func DeleteSubscription(ctx context.Context, db bun.IDB, subscription *domain.Subscription) error {
+	res, err := db.NewDelete().Model(&Subscription{}).Where(
+		"pk = ? AND list_pk = ? AND organization_pk = ? AND version = ?",
+		subscription.PK,
+		subscription.ListPK,
+		subscription.OrganizationPK,
+		subscription.Version-1,
+	).Exec(ctx)
+
+	if err != nil {
+		return queryError(err)
 	}
 
 	if c, err := res.RowsAffected(); err != nil {
 		return err
 	} else if c == 0 {
-		return errors.New("precondition failed")
+		return domain.ErrVersionConflict
 	}
 
 	return nil
 }
 
 // GetSubscription returns a subscription.
-func GetSubscription(db bun.IDB, listPK, pk uuid.UUID) (*domain.Subscription, error) {
+func GetSubscription(ctx context.Context, db bun.IDB, orgPK, listPK, pk uuid.UUID) (*domain.Subscription, error) {
 	model := Subscription{
 		PK:     pk,
 		ListPK: listPK,
 	}
 
-	if err := db.NewSelect().Model(&model).WherePK().Scan(context.Background()); err != nil {
-		return nil, err
+	if err := db.NewSelect().Model(&model).WherePK().Where(
+		"organization_pk = ?",
+		orgPK,
+	).Scan(ctx); err != nil {
+		return nil, queryError(err)
 	}
 
-	return &domain.Subscription{
-		PK:           model.PK,
-		EmailAddress: domain.EmailAddress(model.EmailAddress),
-		Version:      model.Version,
-	}, nil
+	return model.toDomain(), nil
 }
 
 // GetSubscriptionForSubscriber returns a subscription for a subscriber in a list.
-func GetSubscriptionForSubscriber(db bun.IDB, listPK, subscriberPK uuid.UUID) (*domain.Subscription, error) {
+func GetSubscriptionForSubscriber(ctx context.Context, db bun.IDB, orgPK, listPK, subscriberPK uuid.UUID) (*domain.Subscription, error) {
 	model := Subscription{}
 
 	if err := db.NewSelect().Model(&model).Where(
-		"list_pk = ? AND subscriber_pk = ?",
+		"organization_pk = ? AND list_pk = ? AND subscriber_pk = ?",
+		orgPK,
 		listPK,
 		subscriberPK,
-	).Scan(context.Background()); err != nil {
+	).Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	return model.toDomain(), nil
+}
+
+func subscriptionFilter(orgPK uuid.UUID, filter domain.SubscriptionFilter) func(*bun.SelectQuery) *bun.SelectQuery {
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		q = q.Where("organization_pk = ?", orgPK)
+
+		if filter.ListPK != uuid.Nil {
+			q = q.Where("list_pk = ?", filter.ListPK)
+		}
+
+		if filter.SubscriberPK != uuid.Nil {
+			q = q.Where("subscriber_pk = ?", filter.SubscriberPK)
+		}
+
+		if len(filter.States) > 0 {
+			q = q.Where("state IN (?)", bun.In(filter.States))
+		}
+
+		return q
+	}
+}
+
+var subscriptionSortColumns = map[domain.SubscriptionSortField]string{
+	"":                                      "email",
+	domain.SubscriptionSortByEmailAddress:   "email",
+	domain.SubscriptionSortByState:          "state",
+	domain.SubscriptionSortByStateChangedAt: "state_changed_at",
+}
+
+func (m *Subscription) sortValue(column string) string {
+	switch column {
+	case "state":
+		return m.State
+	case "state_changed_at":
+		return m.StateChangedAt.UTC().Format(time.RFC3339Nano)
+	default:
+		return m.EmailAddress
+	}
+}
+
+// ListSubscriptions returns a page of subscriptions.
+func ListSubscriptions(ctx context.Context, db bun.IDB, orgPK uuid.UUID, query domain.SubscriptionQuery) (*domain.SubscriptionPage, error) {
+	column, ok := subscriptionSortColumns[query.SortBy]
+	if !ok {
+		return nil, &domain.ValidationError{Message: fmt.Sprintf("unsupported sort field %q", query.SortBy)}
+	}
+
+	page, err := newPageQuery(column, query.Direction, query.PageRequest)
+	if err != nil {
 		return nil, err
 	}
 
-	return &domain.Subscription{
-		PK:           model.PK,
-		EmailAddress: domain.EmailAddress(model.EmailAddress),
-		Version:      model.Version,
-	}, nil
+	filter := subscriptionFilter(orgPK, query.SubscriptionFilter)
+
+	model := []Subscription{}
+
+	if err := db.NewSelect().Model(&model).Apply(filter).Apply(page.apply).Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	res := &domain.SubscriptionPage{
+		Subscriptions: []*domain.Subscription{},
+	}
+
+	if page.hasNext(len(model)) {
+		model = model[:page.limit]
+		last := model[len(model)-1]
+		res.NextCursor = page.nextCursor(last.sortValue(column), last.PK)
+	}
+
+	for _, subscription := range model {
+		res.Subscriptions = append(res.Subscriptions, subscription.toDomain())
+	}
+
+	if query.WithTotal {
+		if res.Total, err = countTotal(ctx, db.NewSelect().Model((*Subscription)(nil)).Apply(filter)); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
 }
 
-// ListSubscriptions returns a list of subscriptions.
-func ListSubscriptions(db bun.IDB, offset, limit uint32) ([]*domain.Subscription, error) {
+// WalkSubscriptions calls fn for every subscription matching the filter.
+//
+// Subscriptions are read in batches of batchSize ordered by their primary key,
+// so memory use is bounded regardless of the number of subscriptions. Walking
+// stops at the first error returned by fn or when the context is cancelled.
+// Run it within a repeatable read transaction for a consistent view. The batch
+// size must be positive.
+func WalkSubscriptions(
+	ctx context.Context,
+	db bun.IDB,
+	orgPK uuid.UUID,
+	filter domain.SubscriptionFilter,
+	batchSize uint32,
+	fn func(*domain.Subscription) error,
+) error {
+	if batchSize == 0 {
+		return errors.New("walking subscriptions in batches of zero")
+	}
+
+	var after *uuid.UUID
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		model := []Subscription{}
+
+		q := db.NewSelect().Model(&model).Apply(subscriptionFilter(orgPK, filter))
+
+		if after != nil {
+			q = q.Where("pk > ?", *after)
+		}
+
+		if err := q.Order("pk ASC").Limit(int(batchSize)).Scan(ctx); err != nil {
+			return queryError(err)
+		}
+
+		for i := range model {
+			if err := fn(model[i].toDomain()); err != nil {
+				return err
+			}
+		}
+
+		if len(model) < int(batchSize) {
+			return nil
+		}
+
+		after = &model[len(model)-1].PK
+	}
+}
+
+// ListOutdatedSubscriptions returns up to limit subscriptions to a list with
+// data of a schema version older than the given one, ordered by primary key
+// and starting after the given primary key.
+//
+// Forgotten subscriptions hold no data and are never outdated.
+func ListOutdatedSubscriptions(ctx context.Context, db bun.IDB, orgPK, listPK uuid.UUID, schemaVersion uint32, after uuid.UUID, limit uint32) ([]*domain.Subscription, error) {
 	model := []Subscription{}
 
-	if err := db.NewSelect().Model(&model).Scan(context.Background()); err != nil {
-		return nil, err
+	if err := db.NewSelect().Model(&model).Where(
+		"organization_pk = ? AND list_pk = ? AND schema_version < ? AND state <> ? AND pk > ?",
+		orgPK,
+		listPK,
+		schemaVersion,
+		domain.SubscriptionForgotten,
+		after,
+	).Order("pk ASC").Limit(int(limit)).Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	res := []*domain.Subscription{}
+
+	for _, subscription := range model {
+		res = append(res, subscription.toDomain())
+	}
+
+	return res, nil
+}
+
+// ListSubscriptionsForSubscriber returns every subscription of a subscriber.
+func ListSubscriptionsForSubscriber(ctx context.Context, db bun.IDB, orgPK, subscriberPK uuid.UUID) ([]*domain.Subscription, error) {
+	model := []Subscription{}
+
+	if err := db.NewSelect().Model(&model).Where(
+		"organization_pk = ? AND subscriber_pk = ?",
+		orgPK,
+		subscriberPK,
+	).Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	res := []*domain.Subscription{}
+
+	for _, subscription := range model {
+		res = append(res, subscription.toDomain())
+	}
+
+	return res, nil
+}
+
+// ListSuppressedSubscriptions returns up to limit subscriptions in any of the
+// given states whose subscriber's email address is blocked by a suppression,
+// ordered by primary key and starting after the given primary key. A global
+// suppression matches subscriptions of every organization.
+func ListSuppressedSubscriptions(ctx context.Context, db bun.IDB, suppression *domain.Suppression, states []domain.SubscriptionState, after uuid.UUID, limit uint32) ([]*domain.Subscription, error) {
+	subscribers := db.NewSelect().Model((*Subscriber)(nil)).Column("pk")
+
+	if suppression.Domain != "" {
+		subscribers = subscribers.Where("canonical_email LIKE ?", "%@"+likeEscaper.Replace(suppression.Domain))
+	} else {
+		subscribers = subscribers.Where("canonical_email = ?", suppression.EmailAddress)
+	}
+
+	model := []Subscription{}
+
+	q := db.NewSelect().Model(&model).Where(
+		"subscriber_pk IN (?) AND state IN (?) AND pk > ?",
+		subscribers,
+		bun.In(states),
+		after,
+	)
+
+	if !suppression.IsGlobal() {
+		q = q.Where("organization_pk = ?", suppression.OrganizationPK)
+	}
+
+	if err := q.Order("pk ASC").Limit(int(limit)).Scan(ctx); err != nil {
+		return nil, queryError(err)
 	}
 
 	res := []*domain.Subscription{}
 
 	for _, subscription := range model {
-		res = append(res, &domain.Subscription{
-			PK:           subscription.PK,
-			SubscriberPK: subscription.SubscriberPK,
-			ListPK:       subscription.ListPK,
-			EmailAddress: domain.EmailAddress(subscription.EmailAddress),
-			Data:         subscription.Data,
-			Version:      subscription.Version,
-		})
+		res = append(res, subscription.toDomain())
 	}
 
 	return res, nil
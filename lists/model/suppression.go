@@ -0,0 +1,178 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/uptrace/bun"
+)
+
+// Suppression is a database model for a suppressed email address or domain.
+//
+// Global suppressions are stored with a nil organization primary key.
+type Suppression struct {
+	PK             uuid.UUID `bun:"pk,pk"`
+	OrganizationPK uuid.UUID `bun:"organization_pk"`
+	EmailAddress   string    `bun:"email"`
+	Domain         string    `bun:"domain"`
+	Reason         string    `bun:"reason"`
+	CreatedAt      time.Time `bun:"created_at"`
+	ExpiresAt      time.Time `bun:"expires_at,nullzero"`
+
+	bun.BaseModel `bun:"suppressions"`
+}
+
+func newSuppression(suppression *domain.Suppression) *Suppression {
+	return &Suppression{
+		PK:             suppression.PK,
+		OrganizationPK: suppression.OrganizationPK,
+		EmailAddress:   string(suppression.EmailAddress),
+		Domain:         suppression.Domain,
+		Reason:         string(suppression.Reason),
+		CreatedAt:      suppression.CreatedAt,
+		ExpiresAt:      suppression.ExpiresAt,
+	}
+}
+
+func (m *Suppression) toDomain() *domain.Suppression {
+	return &domain.Suppression{
+		PK:             m.PK,
+		OrganizationPK: m.OrganizationPK,
+		EmailAddress:   domain.EmailAddress(m.EmailAddress),
+		Domain:         m.Domain,
+		Reason:         domain.SuppressionReason(m.Reason),
+		CreatedAt:      m.CreatedAt,
+		ExpiresAt:      m.ExpiresAt,
+	}
+}
+
+// CreateSuppression creates a suppression.
+func CreateSuppression(ctx context.Context, db bun.IDB, suppression *domain.Suppression) error {
+	if _, err := db.NewInsert().Model(newSuppression(suppression)).Exec(ctx); err != nil {
+		return queryError(err)
+	}
+
+	return nil
+}
+
+// DeleteSuppression deletes a suppression. A nil organization primary key
+// deletes a global suppression.
+func DeleteSuppression(ctx context.Context, db bun.IDB, orgPK, pk uuid.UUID) error {
+	res, err := db.NewDelete().Model(&Suppression{
+		PK: pk,
+	}).WherePK().Where(
+		"organization_pk = ?",
+		orgPK,
+	).Exec(ctx)
+
+	if err != nil {
+		return queryError(err)
+	}
+
+	if c, err := res.RowsAffected(); err != nil {
+		return err
+	} else if c == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetSuppression returns a suppression. A nil organization primary key returns
+// a global suppression.
+func GetSuppression(ctx context.Context, db bun.IDB, orgPK, pk uuid.UUID) (*domain.Suppression, error) {
+	model := Suppression{
+		PK: pk,
+	}
+
+	if err := db.NewSelect().Model(&model).WherePK().Where(
+		"organization_pk = ?",
+		orgPK,
+	).Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	return model.toDomain(), nil
+}
+
+// ListSuppressionsForEmailAddress returns the suppressions of an organization
+// and the global suppressions that block an email address at the given time.
+func ListSuppressionsForEmailAddress(ctx context.Context, db bun.IDB, orgPK uuid.UUID, addr domain.EmailAddress, now time.Time) ([]*domain.Suppression, error) {
+	model := []Suppression{}
+
+	if err := db.NewSelect().Model(&model).Where(
+		"organization_pk IN (?, ?)",
+		orgPK,
+		uuid.Nil,
+	).Where(
+		"(email = ? OR domain = ?)",
+		addr.Canonical(),
+		addr.Canonical().Domain(),
+	).Where(
+		"(expires_at IS NULL OR expires_at > ?)",
+		now,
+	).Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	res := []*domain.Suppression{}
+
+	for _, suppression := range model {
+		res = append(res, suppression.toDomain())
+	}
+
+	return res, nil
+}
+
+// ListSuppressions returns a page of suppressions. A nil organization primary
+// key lists global suppressions.
+func ListSuppressions(ctx context.Context, db bun.IDB, orgPK uuid.UUID, query domain.SuppressionQuery, now time.Time) (*domain.SuppressionPage, error) {
+	page, err := newPageQuery("created_at", query.Direction, query.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := func(q *bun.SelectQuery) *bun.SelectQuery {
+		q = q.Where("organization_pk = ?", orgPK)
+
+		if len(query.Reasons) > 0 {
+			q = q.Where("reason IN (?)", bun.In(query.Reasons))
+		}
+
+		if !query.IncludeExpired {
+			q = q.Where("(expires_at IS NULL OR expires_at > ?)", now)
+		}
+
+		return q
+	}
+
+	model := []Suppression{}
+
+	if err := db.NewSelect().Model(&model).Apply(filter).Apply(page.apply).Scan(ctx); err != nil {
+		return nil, queryError(err)
+	}
+
+	res := &domain.SuppressionPage{
+		Suppressions: []*domain.Suppression{},
+	}
+
+	if page.hasNext(len(model)) {
+		model = model[:page.limit]
+		last := model[len(model)-1]
+		res.NextCursor = page.nextCursor(last.CreatedAt.UTC().Format(time.RFC3339Nano), last.PK)
+	}
+
+	for _, suppression := range model {
+		res.Suppressions = append(res.Suppressions, suppression.toDomain())
+	}
+
+	if query.WithTotal {
+		if res.Total, err = countTotal(ctx, db.NewSelect().Model((*Suppression)(nil)).Apply(filter)); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,164 @@
+package lists
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/janartodesk/domain-design/lists/model"
+	"github.com/janartodesk/domain-design/pkg/outbox"
+	"github.com/janartodesk/domain-design/pkg/projection"
+	"github.com/uptrace/bun"
+	"google.golang.org/protobuf/proto"
+)
+
+// StatsProjection is the name of the projection maintaining the subscription
+// counts and daily statistics of lists.
+const StatsProjection = "list_stats"
+
+// NewProjector creates a projection runner maintaining the read models served
+// by GetListCounts and ListDailyStats.
+func NewProjector(db *bun.DB) *projection.Runner {
+	return projection.NewRunner(db, statsProjection{})
+}
+
+// statsProjection counts subscriptions by state and the subscriptions that
+// became active or were unsubscribed per day.
+//
+// It keeps the last state it has seen of every subscription, as most events
+// only carry the state a subscription moved to.
+type statsProjection struct{}
+
+func (statsProjection) Name() string {
+	return StatsProjection
+}
+
+func (statsProjection) Reset(ctx context.Context, tx bun.IDB) error {
+	return model.ResetStats(ctx, tx)
+}
+
+func (statsProjection) Apply(ctx context.Context, tx bun.IDB, env *outbox.Envelope, event proto.Message) error {
+	if e, ok := event.(*ListDeleted); ok {
+		return model.DeleteListStats(ctx, tx, uuid.FromBytesOrNil(e.ListPK))
+	}
+
+	if env.GetAggregateType() != AggregateSubscription {
+		return nil
+	}
+
+	pk := uuid.FromBytesOrNil(env.GetAggregateID())
+
+	subscription, err := model.GetStatsSubscription(ctx, tx, pk)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	var from domain.SubscriptionState
+
+	if subscription != nil {
+		from = subscription.State
+	}
+
+	to, ok := statsState(event)
+	if !ok {
+		return nil
+	}
+
+	if subscription == nil {
+		e, ok := event.(*SubscriberSubscribed)
+		if !ok {
+			return nil
+		}
+
+		subscription = &domain.Subscription{
+			PK:             pk,
+			OrganizationPK: uuid.FromBytesOrNil(e.OrganizationPK),
+			ListPK:         uuid.FromBytesOrNil(e.ListPK),
+		}
+	}
+
+	if from == to {
+		return nil
+	}
+
+	active, pending, cancelled := statsCounts(to)
+	fromActive, fromPending, fromCancelled := statsCounts(from)
+
+	if err := model.AddListCounts(ctx,
+		tx,
+		subscription.OrganizationPK,
+		subscription.ListPK,
+		active-fromActive,
+		pending-fromPending,
+		cancelled-fromCancelled,
+	); err != nil {
+		return err
+	}
+
+	var optIns, optOuts int64
+
+	// An expired opt-in returns the subscription to its previous state, which
+	// is neither an opt-in nor an opt-out.
+	if _, expired := event.(*SubscriptionExpired); !expired {
+		switch to {
+		case domain.SubscriptionActive:
+			optIns = 1
+		case domain.SubscriptionUnsubscribed:
+			optOuts = 1
+		}
+	}
+
+	if optIns+optOuts > 0 {
+		if err := model.AddDailyListStats(ctx, tx, subscription.OrganizationPK, subscription.ListPK, env.GetOccurredAt().AsTime(), optIns, optOuts); err != nil {
+			return err
+		}
+	}
+
+	if to == "" {
+		return model.DeleteStatsSubscription(ctx, tx, pk)
+	}
+
+	subscription.State = to
+
+	return model.SaveStatsSubscription(ctx, tx, subscription)
+}
+
+// statsState returns the state a subscription event moves the subscription
+// to. Removed subscriptions have no state.
+func statsState(event proto.Message) (domain.SubscriptionState, bool) {
+	switch e := event.(type) {
+	case *SubscriberSubscribed:
+		return domain.SubscriptionState(e.State), true
+	case *SubscriberOptedIn, *SubscriberResubscribed:
+		return domain.SubscriptionActive, true
+	case *SubscriberUnsubscribed, *SubscriberOptedOut:
+		return domain.SubscriptionUnsubscribed, true
+	case *SubscriptionBounced:
+		return domain.SubscriptionBounced, true
+	case *SubscriptionComplained:
+		return domain.SubscriptionComplained, true
+	case *SubscriptionSuppressed:
+		return domain.SubscriptionSuppressed, true
+	case *SubscriptionForgotten:
+		return domain.SubscriptionForgotten, true
+	case *SubscriptionExpired:
+		return domain.SubscriptionState(e.State), true
+	}
+
+	return "", false
+}
+
+// statsCounts returns the counts a subscription in a state contributes to.
+func statsCounts(state domain.SubscriptionState) (active, pending, cancelled int64) {
+	switch {
+	case state == domain.SubscriptionActive:
+		return 1, 0, 0
+	case state == domain.SubscriptionPending:
+		return 0, 1, 0
+	case state.IsCancelled():
+		return 0, 0, 1
+	}
+
+	return 0, 0, 0
+}
@@ -0,0 +1,159 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/janartodesk/domain-design/lists/model"
+	"github.com/janartodesk/domain-design/pkg/db"
+	"github.com/uptrace/bun"
+)
+
+// StreamBatchSize is the number of subscriptions read at a time when streaming.
+const StreamBatchSize = 1000
+
+// ErrNoDatabase is returned by queries of a Usecase that has no database to
+// read from.
+var ErrNoDatabase = errors.New("no database to query")
+
+// requireDB returns ErrNoDatabase when the Usecase was created without a
+// database.
+func (u *Usecase) requireDB() error {
+	if u.db == nil {
+		return ErrNoDatabase
+	}
+
+	return nil
+}
+
+// GetList returns a subscriber list.
+func (u *Usecase) GetList(ctx context.Context, orgPK, listPK uuid.UUID) (*domain.List, error) {
+	if err := u.requireDB(); err != nil {
+		return nil, err
+	}
+
+	return model.GetList(ctx, u.db, orgPK, listPK)
+}
+
+// ListLists returns a page of subscriber lists.
+func (u *Usecase) ListLists(ctx context.Context, orgPK uuid.UUID, query domain.ListQuery) (*domain.ListPage, error) {
+	if err := u.requireDB(); err != nil {
+		return nil, err
+	}
+
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	return model.ListLists(ctx, u.db, orgPK, query)
+}
+
+// GetSubscriber returns a subscriber.
+func (u *Usecase) GetSubscriber(ctx context.Context, orgPK, subscriberPK uuid.UUID) (*domain.Subscriber, error) {
+	if err := u.requireDB(); err != nil {
+		return nil, err
+	}
+
+	return model.GetSubscriber(ctx, u.db, orgPK, subscriberPK)
+}
+
+// ListSubscribers returns a page of subscribers.
+func (u *Usecase) ListSubscribers(ctx context.Context, orgPK uuid.UUID, query domain.SubscriberQuery) (*domain.SubscriberPage, error) {
+	if err := u.requireDB(); err != nil {
+		return nil, err
+	}
+
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	return model.ListSubscribers(ctx, u.db, orgPK, query)
+}
+
+// GetSubscription returns a subscription.
+func (u *Usecase) GetSubscription(ctx context.Context, orgPK, listPK, subscriptionPK uuid.UUID) (*domain.Subscription, error) {
+	if err := u.requireDB(); err != nil {
+		return nil, err
+	}
+
+	return model.GetSubscription(ctx, u.db, orgPK, listPK, subscriptionPK)
+}
+
+// ListSubscriptions returns a page of subscriptions.
+func (u *Usecase) ListSubscriptions(ctx context.Context, orgPK uuid.UUID, query domain.SubscriptionQuery) (*domain.SubscriptionPage, error) {
+	if err := u.requireDB(); err != nil {
+		return nil, err
+	}
+
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	return model.ListSubscriptions(ctx, u.db, orgPK, query)
+}
+
+// StreamSubscriptions calls fn for every subscription matching the filter.
+//
+// Subscriptions are read in bounded batches within a repeatable read
+// transaction, so fn sees a consistent view however long streaming takes.
+// Streaming stops at the first error returned by fn or when the context is
+// cancelled.
+func (u *Usecase) StreamSubscriptions(ctx context.Context, orgPK uuid.UUID, filter domain.SubscriptionFilter, fn func(*domain.Subscription) error) error {
+	if err := u.requireDB(); err != nil {
+		return err
+	}
+
+	return db.WithSnapshot(ctx, u.db, func(ctx context.Context, tx bun.Tx) error {
+		return model.WalkSubscriptions(ctx, tx, orgPK, filter, StreamBatchSize, fn)
+	})
+}
+
+// GetListCounts returns the numbers of subscriptions to a list by state.
+//
+// The counts are maintained by the projection runner created with
+// NewProjector and lag behind the subscriptions until it has caught up.
+func (u *Usecase) GetListCounts(ctx context.Context, orgPK, listPK uuid.UUID) (*domain.ListCounts, error) {
+	if err := u.requireDB(); err != nil {
+		return nil, err
+	}
+
+	counts, err := model.GetListCounts(ctx, u.db, orgPK, listPK)
+	if !errors.Is(err, domain.ErrNotFound) {
+		return counts, err
+	}
+
+	if _, err := model.GetList(ctx, u.db, orgPK, listPK); err != nil {
+		return nil, err
+	}
+
+	return &domain.ListCounts{
+		ListPK: listPK,
+	}, nil
+}
+
+// ListDailyStats returns the numbers of subscriptions to a list that became
+// active and that were unsubscribed per day, from the day of from through the
+// day of to. Days without changes are skipped.
+//
+// The statistics are maintained by the projection runner created with
+// NewProjector.
+func (u *Usecase) ListDailyStats(ctx context.Context, orgPK, listPK uuid.UUID, from, to time.Time) ([]*domain.DailyListStats, error) {
+	from, to = domain.StatsDay(from), domain.StatsDay(to)
+
+	if to.Before(from) || to.Sub(from) >= domain.MaxStatsDays*24*time.Hour {
+		return nil, domain.ErrInvalidStatsRange
+	}
+
+	if err := u.requireDB(); err != nil {
+		return nil, err
+	}
+
+	if _, err := model.GetList(ctx, u.db, orgPK, listPK); err != nil {
+		return nil, err
+	}
+
+	return model.ListDailyListStats(ctx, u.db, orgPK, listPK, from, to)
+}
@@ -0,0 +1,128 @@
+package lists
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/janartodesk/domain-design/pkg/outbox"
+)
+
+// The repositories below persist what the subscriber list commands change.
+// Lookups return domain.ErrNotFound when nothing matches.
+//
+// Versioned aggregates at version one are created, while others are updated
+// with their previous version as the precondition. Saves return
+// domain.ErrDuplicate when creating an aggregate that exists already and
+// domain.ErrVersionConflict when the previous version is not the stored one.
+// Saves are passed the enveloped event that produced the saved version, for
+// repositories that persist aggregates as events.
+
+// OrganizationRepository persists organizations.
+type OrganizationRepository interface {
+	GetOrganization(ctx context.Context, pk uuid.UUID) (*domain.Organization, error)
+	CreateOrganization(ctx context.Context, org *domain.Organization) error
+	UpdateOrganization(ctx context.Context, org *domain.Organization) error
+}
+
+// ListRepository persists subscriber lists.
+type ListRepository interface {
+	GetList(ctx context.Context, orgPK, listPK uuid.UUID) (*domain.List, error)
+	SaveList(ctx context.Context, list *domain.List, env *outbox.Envelope) error
+	// DeleteList deletes a list. The list is passed at its last saved
+	// version, while the event is its deletion.
+	DeleteList(ctx context.Context, list *domain.List, env *outbox.Envelope) error
+}
+
+// SubscriberRepository persists subscribers.
+type SubscriberRepository interface {
+	GetSubscriber(ctx context.Context, orgPK, subscriberPK uuid.UUID) (*domain.Subscriber, error)
+	// GetSubscriberByEmailAddress matches subscribers on the canonical form
+	// of their email address.
+	GetSubscriberByEmailAddress(ctx context.Context, orgPK uuid.UUID, addr domain.EmailAddress) (*domain.Subscriber, error)
+	SaveSubscriber(ctx context.Context, subscriber *domain.Subscriber, env *outbox.Envelope) error
+}
+
+// SubscriptionRepository persists subscriptions.
+type SubscriptionRepository interface {
+	GetSubscription(ctx context.Context, orgPK, listPK, subscriptionPK uuid.UUID) (*domain.Subscription, error)
+	GetSubscriptionForSubscriber(ctx context.Context, orgPK, listPK, subscriberPK uuid.UUID) (*domain.Subscription, error)
+	ListSubscriptionsForSubscriber(ctx context.Context, orgPK, subscriberPK uuid.UUID) ([]*domain.Subscription, error)
+	// ListOutdatedSubscriptions returns up to limit subscriptions to a list
+	// that are not forgotten and hold data of a schema version older than
+	// the given one, ordered by primary key and starting after the given
+	// primary key.
+	ListOutdatedSubscriptions(ctx context.Context, orgPK, listPK uuid.UUID, schemaVersion uint32, after uuid.UUID, limit uint32) ([]*domain.Subscription, error)
+	// ListSuppressedSubscriptions returns up to limit subscriptions in any of
+	// the given states whose subscriber's email address is blocked by a
+	// suppression, ordered by primary key and starting after the given
+	// primary key.
+	ListSuppressedSubscriptions(ctx context.Context, suppression *domain.Suppression, states []domain.SubscriptionState, after uuid.UUID, limit uint32) ([]*domain.Subscription, error)
+	SaveSubscription(ctx context.Context, subscription *domain.Subscription, env *outbox.Envelope) error
+	// DeleteSubscription deletes a subscription at the version the event
+	// produced.
+	DeleteSubscription(ctx context.Context, subscription *domain.Subscription, env *outbox.Envelope) error
+}
+
+// ConfirmationRepository persists subscription confirmations.
+type ConfirmationRepository interface {
+	CreateConfirmation(ctx context.Context, confirmation *domain.Confirmation) error
+	DeleteConfirmation(ctx context.Context, confirmation *domain.Confirmation) error
+	DeleteConfirmationsForSubscription(ctx context.Context, orgPK, subscriptionPK uuid.UUID) error
+	GetConfirmationByToken(ctx context.Context, orgPK uuid.UUID, token string) (*domain.Confirmation, error)
+	// GetConfirmationForSubscription returns the latest confirmation of a
+	// subscription, without its token.
+	GetConfirmationForSubscription(ctx context.Context, orgPK, subscriptionPK uuid.UUID) (*domain.Confirmation, error)
+	// ListExpiredConfirmations returns up to limit confirmations of every
+	// organization that have expired at the given time, soonest expired
+	// first. A limit of zero returns all of them.
+	ListExpiredConfirmations(ctx context.Context, now time.Time, limit uint32) ([]*domain.Confirmation, error)
+}
+
+// SuppressionRepository persists suppressions. A nil organization primary key
+// refers to global suppressions.
+type SuppressionRepository interface {
+	CreateSuppression(ctx context.Context, suppression *domain.Suppression) error
+	DeleteSuppression(ctx context.Context, orgPK, pk uuid.UUID) error
+	// ListSuppressionsForEmailAddress returns the suppressions of an
+	// organization and the global suppressions that block an email address
+	// at the given time.
+	ListSuppressionsForEmailAddress(ctx context.Context, orgPK uuid.UUID, addr domain.EmailAddress, now time.Time) ([]*domain.Suppression, error)
+}
+
+// FeedbackRepository records the feedback reports that have been processed, so
+// that a report delivered again is not applied twice.
+type FeedbackRepository interface {
+	// RecordReport records a processed report about a recipient of a
+	// message and reports whether it had not been recorded before.
+	RecordReport(ctx context.Context, orgPK uuid.UUID, messageID string, recipient domain.EmailAddress, reportType string) (bool, error)
+	// DeleteReportsForEmailAddress deletes the records of the reports about
+	// an email address.
+	DeleteReportsForEmailAddress(ctx context.Context, orgPK uuid.UUID, addr domain.EmailAddress) error
+}
+
+// Transaction gives a unit of work access to the repositories.
+type Transaction interface {
+	Organizations() OrganizationRepository
+	Lists() ListRepository
+	Subscribers() SubscriberRepository
+	Subscriptions() SubscriptionRepository
+	Confirmations() ConfirmationRepository
+	Suppressions() SuppressionRepository
+	Feedback() FeedbackRepository
+
+	// Enqueue stores an event to be published once the unit of work has
+	// been committed.
+	Enqueue(ctx context.Context, env *outbox.Envelope) error
+	// Redact rewrites the stored events an aggregate raised with redact,
+	// wherever they are kept once published.
+	Redact(ctx context.Context, aggregateType string, aggregateID uuid.UUID, redact outbox.RedactFunc) error
+}
+
+// UnitOfWork runs commands atomically.
+type UnitOfWork interface {
+	// Do runs fn in a transaction that is committed when fn returns nil and
+	// rolled back otherwise.
+	Do(ctx context.Context, fn func(Transaction) error) error
+}
@@ -0,0 +1,148 @@
+package lists
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+)
+
+// MigrationBatchSize is the number of subscriptions migrated per transaction.
+const MigrationBatchSize = 500
+
+// SchemaMigration reports the outcome of migrating subscriptions to the
+// current schema version of their list.
+type SchemaMigration struct {
+	SchemaVersion uint32
+	Migrated      int
+	// Failed maps subscriptions whose data does not conform to the schema to
+	// their validation errors. They are left at their previous schema version.
+	Failed map[uuid.UUID]error
+}
+
+// ChangeListSchema replaces the custom field schema of a subscriber list at the
+// expected version.
+//
+// Existing subscriptions keep their data until they are migrated to the new
+// schema version with MigrateSubscriptions.
+func (u *Usecase) ChangeListSchema(ctx context.Context, orgPK, listPK uuid.UUID, fields []domain.Field, expectedVersion uint32) (*domain.List, error) {
+	var list *domain.List
+
+	err := u.transaction(ctx, func(tx Transaction) error {
+		previous, err := tx.Lists().GetList(ctx, orgPK, listPK)
+		if err != nil {
+			return err
+		}
+
+		if err := domain.CheckVersion(previous.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		list, err = domain.ChangeListSchema(*previous, fields)
+		if err != nil {
+			return err
+		}
+
+		schemaFields, err := fieldsList(list.Schema.Fields)
+		if err != nil {
+			return err
+		}
+
+		return saveList(ctx, tx, list, &ListSchemaChanged{
+			ListPK:         list.PK.Bytes(),
+			OrganizationPK: list.OrganizationPK.Bytes(),
+			SchemaVersion:  list.Schema.Version,
+			Fields:         schemaFields,
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// MigrateSubscriptions migrates the data of a list's subscriptions to the
+// current schema version of the list, in batches of MigrationBatchSize.
+func (u *Usecase) MigrateSubscriptions(ctx context.Context, orgPK, listPK uuid.UUID) (*SchemaMigration, error) {
+	res := &SchemaMigration{
+		Failed: map[uuid.UUID]error{},
+	}
+
+	after := uuid.Nil
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+
+		var (
+			n        int
+			migrated int
+			failed   map[uuid.UUID]error
+			last     uuid.UUID
+		)
+
+		err := u.transaction(ctx, func(tx Transaction) error {
+			n, migrated, failed = 0, 0, map[uuid.UUID]error{}
+
+			list, err := tx.Lists().GetList(ctx, orgPK, listPK)
+			if err != nil {
+				return err
+			}
+
+			res.SchemaVersion = list.Schema.Version
+
+			subscriptions, err := tx.Subscriptions().ListOutdatedSubscriptions(ctx, orgPK, listPK, list.Schema.Version, after, MigrationBatchSize)
+			if err != nil {
+				return err
+			}
+
+			n = len(subscriptions)
+
+			for _, subscription := range subscriptions {
+				last = subscription.PK
+
+				migratedSubscription, err := domain.MigrateSubscription(*subscription, *list)
+				if err != nil {
+					if errors.Is(err, domain.ErrValidation) {
+						failed[subscription.PK] = err
+						continue
+					}
+
+					return err
+				}
+
+				event, err := newSubscriptionDataChanged(migratedSubscription)
+				if err != nil {
+					return err
+				}
+
+				if err := saveSubscription(ctx, tx, migratedSubscription, event); err != nil {
+					return err
+				}
+
+				migrated++
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return res, err
+		}
+
+		after = last
+		res.Migrated += migrated
+
+		for pk, err := range failed {
+			res.Failed[pk] = err
+		}
+
+		if n < MigrationBatchSize {
+			return res, nil
+		}
+	}
+}
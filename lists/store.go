@@ -0,0 +1,420 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/janartodesk/domain-design/lists/model"
+	"github.com/janartodesk/domain-design/pkg/consumer"
+	"github.com/janartodesk/domain-design/pkg/db"
+	"github.com/janartodesk/domain-design/pkg/outbox"
+	"github.com/uptrace/bun"
+	"google.golang.org/protobuf/proto"
+)
+
+// Persistence selects how the list, subscriber and subscription aggregates
+// are persisted.
+type Persistence int
+
+const (
+	// StatePersistence stores the current state of each aggregate as a table
+	// row. It is the default.
+	StatePersistence Persistence = iota
+	// EventSourcedPersistence stores each aggregate as an append-only stream
+	// of its events and rebuilds it by folding them. The tables of
+	// StatePersistence are kept up to date as the read model queries use.
+	EventSourcedPersistence
+)
+
+// aggregateStore loads and saves the list, subscriber and subscription
+// aggregates within a database transaction, as their repositories describe.
+type aggregateStore interface {
+	GetList(ctx context.Context, db bun.IDB, orgPK, listPK uuid.UUID) (*domain.List, error)
+	SaveList(ctx context.Context, db bun.IDB, list *domain.List, env *outbox.Envelope) error
+	DeleteList(ctx context.Context, db bun.IDB, list *domain.List, env *outbox.Envelope) error
+
+	GetSubscriber(ctx context.Context, db bun.IDB, orgPK, subscriberPK uuid.UUID) (*domain.Subscriber, error)
+	GetSubscriberByEmailAddress(ctx context.Context, db bun.IDB, orgPK uuid.UUID, addr domain.EmailAddress) (*domain.Subscriber, error)
+	SaveSubscriber(ctx context.Context, db bun.IDB, subscriber *domain.Subscriber, env *outbox.Envelope) error
+
+	GetSubscription(ctx context.Context, db bun.IDB, orgPK, listPK, subscriptionPK uuid.UUID) (*domain.Subscription, error)
+	GetSubscriptionForSubscriber(ctx context.Context, db bun.IDB, orgPK, listPK, subscriberPK uuid.UUID) (*domain.Subscription, error)
+	ListSubscriptionsForSubscriber(ctx context.Context, db bun.IDB, orgPK, subscriberPK uuid.UUID) ([]*domain.Subscription, error)
+	ListOutdatedSubscriptions(ctx context.Context, db bun.IDB, orgPK, listPK uuid.UUID, schemaVersion uint32, after uuid.UUID, limit uint32) ([]*domain.Subscription, error)
+	ListSuppressedSubscriptions(ctx context.Context, db bun.IDB, suppression *domain.Suppression, states []domain.SubscriptionState, after uuid.UUID, limit uint32) ([]*domain.Subscription, error)
+	SaveSubscription(ctx context.Context, db bun.IDB, subscription *domain.Subscription, env *outbox.Envelope) error
+	DeleteSubscription(ctx context.Context, db bun.IDB, subscription *domain.Subscription, env *outbox.Envelope) error
+}
+
+func (u *Usecase) aggregates() aggregateStore {
+	if u.Persistence == EventSourcedPersistence {
+		return eventSourcedStore{}
+	}
+
+	return stateStore{}
+}
+
+// transaction runs fn in a unit of work, re-running it as the retry policy of
+// the Usecase allows. Without a unit of work of its own, the Usecase runs it in
+// a database transaction, or in a savepoint when the context carries one
+// already. Savepoints are not retried, as a failure for a concurrent command
+// aborts the outer transaction as well.
+func (u *Usecase) transaction(ctx context.Context, fn func(Transaction) error) error {
+	var uow UnitOfWork = bunUnitOfWork{
+		db:    u.db,
+		store: u.aggregates(),
+	}
+
+	if u.uow != nil {
+		uow = u.uow
+	} else if db.InTransaction(ctx, u.db) {
+		return uow.Do(ctx, fn)
+	}
+
+	policy := u.Retry
+	if policy.Retryable == nil {
+		policy.Retryable = isTransient
+	}
+
+	return policy.Do(ctx, func() error {
+		return uow.Do(ctx, fn)
+	})
+}
+
+// isTransient reports whether a command failed for a concurrent command and
+// may succeed when run again. Conflicts with the version expected by a client
+// are not transient; the client has to re-read the entity.
+func isTransient(err error) bool {
+	var conflict *domain.VersionConflictError
+	if errors.As(err, &conflict) {
+		return false
+	}
+
+	return errors.Is(err, domain.ErrVersionConflict) || db.IsSerializationFailure(err)
+}
+
+// enqueue wraps an event and stores it to be published.
+func enqueue(ctx context.Context, tx Transaction, event proto.Message, meta outbox.Metadata) error {
+	env, err := outbox.Wrap(ctx, event, meta)
+	if err != nil {
+		return err
+	}
+
+	return tx.Enqueue(ctx, env)
+}
+
+// saveList saves a list along with the event that produced its version.
+func saveList(ctx context.Context, tx Transaction, list *domain.List, event proto.Message) error {
+	env, err := outbox.Wrap(ctx, event, listEvent(list))
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Lists().SaveList(ctx, list, env); err != nil {
+		return err
+	}
+
+	return tx.Enqueue(ctx, env)
+}
+
+// deleteList deletes a list. The deletion is the last version of the list.
+func deleteList(ctx context.Context, tx Transaction, list *domain.List, event proto.Message) error {
+	meta := listEvent(list)
+	meta.AggregateVersion++
+
+	env, err := outbox.Wrap(ctx, event, meta)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Lists().DeleteList(ctx, list, env); err != nil {
+		return err
+	}
+
+	return tx.Enqueue(ctx, env)
+}
+
+// saveSubscriber saves a subscriber along with the event that produced its
+// version.
+func saveSubscriber(ctx context.Context, tx Transaction, subscriber *domain.Subscriber, event proto.Message) error {
+	env, err := outbox.Wrap(ctx, event, subscriberEvent(subscriber))
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Subscribers().SaveSubscriber(ctx, subscriber, env); err != nil {
+		return err
+	}
+
+	return tx.Enqueue(ctx, env)
+}
+
+// saveSubscription saves a subscription along with the event that produced its
+// version.
+func saveSubscription(ctx context.Context, tx Transaction, subscription *domain.Subscription, event proto.Message) error {
+	env, err := outbox.Wrap(ctx, event, subscriptionEvent(subscription))
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Subscriptions().SaveSubscription(ctx, subscription, env); err != nil {
+		return err
+	}
+
+	return tx.Enqueue(ctx, env)
+}
+
+// deleteSubscription deletes a subscription along with the event that
+// produced its last version.
+func deleteSubscription(ctx context.Context, tx Transaction, subscription *domain.Subscription, event proto.Message) error {
+	env, err := outbox.Wrap(ctx, event, subscriptionEvent(subscription))
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Subscriptions().DeleteSubscription(ctx, subscription, env); err != nil {
+		return err
+	}
+
+	return tx.Enqueue(ctx, env)
+}
+
+// bunUnitOfWork runs units of work in database transactions. Events are
+// written to the outbox.
+type bunUnitOfWork struct {
+	db    *bun.DB
+	store aggregateStore
+}
+
+func (w bunUnitOfWork) Do(ctx context.Context, fn func(Transaction) error) error {
+	return db.WithTransaction(ctx, w.db, func(ctx context.Context, tx bun.Tx) error {
+		return fn(&bunTransaction{
+			tx:    tx,
+			store: w.store,
+		})
+	})
+}
+
+// bunTransaction implements every repository on a database transaction.
+type bunTransaction struct {
+	tx    bun.IDB
+	store aggregateStore
+}
+
+func (t *bunTransaction) Organizations() OrganizationRepository {
+	return t
+}
+
+func (t *bunTransaction) Lists() ListRepository {
+	return t
+}
+
+func (t *bunTransaction) Subscribers() SubscriberRepository {
+	return t
+}
+
+func (t *bunTransaction) Subscriptions() SubscriptionRepository {
+	return t
+}
+
+func (t *bunTransaction) Confirmations() ConfirmationRepository {
+	return t
+}
+
+func (t *bunTransaction) Suppressions() SuppressionRepository {
+	return t
+}
+
+func (t *bunTransaction) Feedback() FeedbackRepository {
+	return t
+}
+
+func (t *bunTransaction) Enqueue(ctx context.Context, env *outbox.Envelope) error {
+	return outbox.EnqueueEnvelope(ctx, t.tx, env)
+}
+
+func (t *bunTransaction) Redact(ctx context.Context, aggregateType string, aggregateID uuid.UUID, redact outbox.RedactFunc) error {
+	if err := consumer.RedactDeadLetters(ctx, t.tx, aggregateType, aggregateID, redact); err != nil {
+		return err
+	}
+
+	return outbox.Redact(ctx, t.tx, aggregateType, aggregateID, redact)
+}
+
+func (t *bunTransaction) GetOrganization(ctx context.Context, pk uuid.UUID) (*domain.Organization, error) {
+	return model.GetOrganization(ctx, t.tx, pk)
+}
+
+func (t *bunTransaction) CreateOrganization(ctx context.Context, org *domain.Organization) error {
+	return model.CreateOrganization(ctx, t.tx, org)
+}
+
+func (t *bunTransaction) UpdateOrganization(ctx context.Context, org *domain.Organization) error {
+	return model.UpdateOrganization(ctx, t.tx, org)
+}
+
+func (t *bunTransaction) GetList(ctx context.Context, orgPK, listPK uuid.UUID) (*domain.List, error) {
+	return t.store.GetList(ctx, t.tx, orgPK, listPK)
+}
+
+func (t *bunTransaction) SaveList(ctx context.Context, list *domain.List, env *outbox.Envelope) error {
+	return t.store.SaveList(ctx, t.tx, list, env)
+}
+
+func (t *bunTransaction) DeleteList(ctx context.Context, list *domain.List, env *outbox.Envelope) error {
+	return t.store.DeleteList(ctx, t.tx, list, env)
+}
+
+func (t *bunTransaction) GetSubscriber(ctx context.Context, orgPK, subscriberPK uuid.UUID) (*domain.Subscriber, error) {
+	return t.store.GetSubscriber(ctx, t.tx, orgPK, subscriberPK)
+}
+
+func (t *bunTransaction) GetSubscriberByEmailAddress(ctx context.Context, orgPK uuid.UUID, addr domain.EmailAddress) (*domain.Subscriber, error) {
+	return t.store.GetSubscriberByEmailAddress(ctx, t.tx, orgPK, addr)
+}
+
+func (t *bunTransaction) SaveSubscriber(ctx context.Context, subscriber *domain.Subscriber, env *outbox.Envelope) error {
+	return t.store.SaveSubscriber(ctx, t.tx, subscriber, env)
+}
+
+func (t *bunTransaction) GetSubscription(ctx context.Context, orgPK, listPK, subscriptionPK uuid.UUID) (*domain.Subscription, error) {
+	return t.store.GetSubscription(ctx, t.tx, orgPK, listPK, subscriptionPK)
+}
+
+func (t *bunTransaction) GetSubscriptionForSubscriber(ctx context.Context, orgPK, listPK, subscriberPK uuid.UUID) (*domain.Subscription, error) {
+	return t.store.GetSubscriptionForSubscriber(ctx, t.tx, orgPK, listPK, subscriberPK)
+}
+
+func (t *bunTransaction) ListSubscriptionsForSubscriber(ctx context.Context, orgPK, subscriberPK uuid.UUID) ([]*domain.Subscription, error) {
+	return t.store.ListSubscriptionsForSubscriber(ctx, t.tx, orgPK, subscriberPK)
+}
+
+func (t *bunTransaction) ListOutdatedSubscriptions(ctx context.Context, orgPK, listPK uuid.UUID, schemaVersion uint32, after uuid.UUID, limit uint32) ([]*domain.Subscription, error) {
+	return t.store.ListOutdatedSubscriptions(ctx, t.tx, orgPK, listPK, schemaVersion, after, limit)
+}
+
+func (t *bunTransaction) ListSuppressedSubscriptions(ctx context.Context, suppression *domain.Suppression, states []domain.SubscriptionState, after uuid.UUID, limit uint32) ([]*domain.Subscription, error) {
+	return t.store.ListSuppressedSubscriptions(ctx, t.tx, suppression, states, after, limit)
+}
+
+func (t *bunTransaction) SaveSubscription(ctx context.Context, subscription *domain.Subscription, env *outbox.Envelope) error {
+	return t.store.SaveSubscription(ctx, t.tx, subscription, env)
+}
+
+func (t *bunTransaction) DeleteSubscription(ctx context.Context, subscription *domain.Subscription, env *outbox.Envelope) error {
+	return t.store.DeleteSubscription(ctx, t.tx, subscription, env)
+}
+
+func (t *bunTransaction) CreateConfirmation(ctx context.Context, confirmation *domain.Confirmation) error {
+	return model.CreateConfirmation(ctx, t.tx, confirmation)
+}
+
+func (t *bunTransaction) DeleteConfirmation(ctx context.Context, confirmation *domain.Confirmation) error {
+	return model.DeleteConfirmation(ctx, t.tx, confirmation)
+}
+
+func (t *bunTransaction) DeleteConfirmationsForSubscription(ctx context.Context, orgPK, subscriptionPK uuid.UUID) error {
+	return model.DeleteConfirmationsForSubscription(ctx, t.tx, orgPK, subscriptionPK)
+}
+
+func (t *bunTransaction) GetConfirmationByToken(ctx context.Context, orgPK uuid.UUID, token string) (*domain.Confirmation, error) {
+	return model.GetConfirmationByToken(ctx, t.tx, orgPK, token)
+}
+
+func (t *bunTransaction) GetConfirmationForSubscription(ctx context.Context, orgPK, subscriptionPK uuid.UUID) (*domain.Confirmation, error) {
+	return model.GetConfirmationForSubscription(ctx, t.tx, orgPK, subscriptionPK)
+}
+
+func (t *bunTransaction) ListExpiredConfirmations(ctx context.Context, now time.Time, limit uint32) ([]*domain.Confirmation, error) {
+	return model.ListExpiredConfirmations(ctx, t.tx, now, limit)
+}
+
+func (t *bunTransaction) CreateSuppression(ctx context.Context, suppression *domain.Suppression) error {
+	return model.CreateSuppression(ctx, t.tx, suppression)
+}
+
+func (t *bunTransaction) DeleteSuppression(ctx context.Context, orgPK, pk uuid.UUID) error {
+	return model.DeleteSuppression(ctx, t.tx, orgPK, pk)
+}
+
+func (t *bunTransaction) ListSuppressionsForEmailAddress(ctx context.Context, orgPK uuid.UUID, addr domain.EmailAddress, now time.Time) ([]*domain.Suppression, error) {
+	return model.ListSuppressionsForEmailAddress(ctx, t.tx, orgPK, addr, now)
+}
+
+func (t *bunTransaction) RecordReport(ctx context.Context, orgPK uuid.UUID, messageID string, recipient domain.EmailAddress, reportType string) (bool, error) {
+	return model.RecordFeedbackReport(ctx, t.tx, orgPK, messageID, recipient, reportType)
+}
+
+func (t *bunTransaction) DeleteReportsForEmailAddress(ctx context.Context, orgPK uuid.UUID, addr domain.EmailAddress) error {
+	return model.DeleteFeedbackReportsForEmailAddress(ctx, t.tx, orgPK, addr)
+}
+
+// stateStore persists aggregates as table rows.
+type stateStore struct{}
+
+func (stateStore) GetList(ctx context.Context, db bun.IDB, orgPK, listPK uuid.UUID) (*domain.List, error) {
+	return model.GetList(ctx, db, orgPK, listPK)
+}
+
+func (stateStore) SaveList(ctx context.Context, db bun.IDB, list *domain.List, env *outbox.Envelope) error {
+	if list.Version == 1 {
+		return model.CreateList(ctx, db, list)
+	}
+
+	return model.UpdateList(ctx, db, list)
+}
+
+func (stateStore) DeleteList(ctx context.Context, db bun.IDB, list *domain.List, env *outbox.Envelope) error {
+	return model.DeleteList(ctx, db, list)
+}
+
+func (stateStore) GetSubscriber(ctx context.Context, db bun.IDB, orgPK, subscriberPK uuid.UUID) (*domain.Subscriber, error) {
+	return model.GetSubscriber(ctx, db, orgPK, subscriberPK)
+}
+
+func (stateStore) GetSubscriberByEmailAddress(ctx context.Context, db bun.IDB, orgPK uuid.UUID, addr domain.EmailAddress) (*domain.Subscriber, error) {
+	return model.GetSubscriberByEmailAddress(ctx, db, orgPK, addr)
+}
+
+func (stateStore) SaveSubscriber(ctx context.Context, db bun.IDB, subscriber *domain.Subscriber, env *outbox.Envelope) error {
+	if subscriber.Version == 1 {
+		return model.CreateSubscriber(ctx, db, subscriber)
+	}
+
+	return model.UpdateSubscriber(ctx, db, subscriber)
+}
+
+func (stateStore) GetSubscription(ctx context.Context, db bun.IDB, orgPK, listPK, subscriptionPK uuid.UUID) (*domain.Subscription, error) {
+	return model.GetSubscription(ctx, db, orgPK, listPK, subscriptionPK)
+}
+
+func (stateStore) GetSubscriptionForSubscriber(ctx context.Context, db bun.IDB, orgPK, listPK, subscriberPK uuid.UUID) (*domain.Subscription, error) {
+	return model.GetSubscriptionForSubscriber(ctx, db, orgPK, listPK, subscriberPK)
+}
+
+func (stateStore) ListSubscriptionsForSubscriber(ctx context.Context, db bun.IDB, orgPK, subscriberPK uuid.UUID) ([]*domain.Subscription, error) {
+	return model.ListSubscriptionsForSubscriber(ctx, db, orgPK, subscriberPK)
+}
+
+func (stateStore) ListOutdatedSubscriptions(ctx context.Context, db bun.IDB, orgPK, listPK uuid.UUID, schemaVersion uint32, after uuid.UUID, limit uint32) ([]*domain.Subscription, error) {
+	return model.ListOutdatedSubscriptions(ctx, db, orgPK, listPK, schemaVersion, after, limit)
+}
+
+func (stateStore) ListSuppressedSubscriptions(ctx context.Context, db bun.IDB, suppression *domain.Suppression, states []domain.SubscriptionState, after uuid.UUID, limit uint32) ([]*domain.Subscription, error) {
+	return model.ListSuppressedSubscriptions(ctx, db, suppression, states, after, limit)
+}
+
+func (stateStore) SaveSubscription(ctx context.Context, db bun.IDB, subscription *domain.Subscription, env *outbox.Envelope) error {
+	if subscription.Version == 1 {
+		return model.CreateSubscription(ctx, db, subscription)
+	}
+
+	return model.UpdateSubscription(ctx, db, subscription)
+}
+
+func (stateStore) DeleteSubscription(ctx context.Context, db bun.IDB, subscription *domain.Subscription, env *outbox.Envelope) error {
+	return model.DeleteSubscription(ctx, db, subscription)
+}
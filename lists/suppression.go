@@ -0,0 +1,222 @@
+package lists
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/lists/domain"
+	"github.com/janartodesk/domain-design/lists/model"
+	"github.com/janartodesk/domain-design/pkg/outbox"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SuppressionBatchSize is the number of subscriptions stopped per batch by a
+// new suppression.
+const SuppressionBatchSize = 500
+
+// suppressibleStates are the states of subscriptions stopped by a new
+// suppression.
+var suppressibleStates = []domain.SubscriptionState{
+	domain.SubscriptionPending,
+	domain.SubscriptionActive,
+}
+
+// AddSuppression suppresses an email address or, when target has no @, a
+// domain within an organization. A zero expiry never expires.
+//
+// Pending and active subscriptions of the organization blocked by the
+// suppression are stopped in batches of SuppressionBatchSize, the first along
+// with adding the suppression and each of the others in a transaction of its
+// own. When a later batch fails, the suppression stays added and the error is
+// returned.
+func (u *Usecase) AddSuppression(ctx context.Context, orgPK uuid.UUID, target string, reason domain.SuppressionReason, expiresAt time.Time) (*domain.Suppression, error) {
+	if orgPK == uuid.Nil {
+		return nil, domain.ErrNotFound
+	}
+
+	return u.addSuppression(ctx, orgPK, target, reason, expiresAt)
+}
+
+// AddGlobalSuppression suppresses an email address or domain across every
+// organization.
+//
+// Pending and active subscriptions of every organization blocked by the
+// suppression are stopped in batches, like with AddSuppression.
+func (u *Usecase) AddGlobalSuppression(ctx context.Context, target string, reason domain.SuppressionReason, expiresAt time.Time) (*domain.Suppression, error) {
+	return u.addSuppression(ctx, uuid.Nil, target, reason, expiresAt)
+}
+
+// RemoveSuppression lifts a suppression of an organization.
+//
+// Subscriptions stopped by the suppression stay suppressed until the
+// subscriber subscribes again.
+func (u *Usecase) RemoveSuppression(ctx context.Context, orgPK, suppressionPK uuid.UUID) error {
+	if orgPK == uuid.Nil {
+		return domain.ErrNotFound
+	}
+
+	return u.removeSuppression(ctx, orgPK, suppressionPK)
+}
+
+// RemoveGlobalSuppression lifts a global suppression.
+func (u *Usecase) RemoveGlobalSuppression(ctx context.Context, suppressionPK uuid.UUID) error {
+	return u.removeSuppression(ctx, uuid.Nil, suppressionPK)
+}
+
+// ListSuppressions returns a page of suppressions of an organization.
+func (u *Usecase) ListSuppressions(ctx context.Context, orgPK uuid.UUID, query domain.SuppressionQuery) (*domain.SuppressionPage, error) {
+	if orgPK == uuid.Nil {
+		return nil, domain.ErrNotFound
+	}
+
+	return u.listSuppressions(ctx, orgPK, query)
+}
+
+// ListGlobalSuppressions returns a page of global suppressions.
+func (u *Usecase) ListGlobalSuppressions(ctx context.Context, query domain.SuppressionQuery) (*domain.SuppressionPage, error) {
+	return u.listSuppressions(ctx, uuid.Nil, query)
+}
+
+func (u *Usecase) listSuppressions(ctx context.Context, orgPK uuid.UUID, query domain.SuppressionQuery) (*domain.SuppressionPage, error) {
+	if err := u.requireDB(); err != nil {
+		return nil, err
+	}
+
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	return model.ListSuppressions(ctx, u.db, orgPK, query, time.Now())
+}
+
+func (u *Usecase) addSuppression(ctx context.Context, orgPK uuid.UUID, target string, reason domain.SuppressionReason, expiresAt time.Time) (*domain.Suppression, error) {
+	suppression, err := domain.CreateSuppression(orgPK, target, reason, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		after uuid.UUID
+		more  bool
+	)
+
+	if err := u.transaction(ctx, func(tx Transaction) error {
+		if !suppression.IsGlobal() {
+			if _, err := tx.Organizations().GetOrganization(ctx, suppression.OrganizationPK); err != nil {
+				return err
+			}
+		}
+
+		if err := u.createSuppression(ctx, tx, suppression); err != nil {
+			return err
+		}
+
+		var err error
+		after, more, err = u.suppressSubscriptions(ctx, tx, suppression, uuid.Nil)
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	for more {
+		from := after
+
+		if err := u.transaction(ctx, func(tx Transaction) error {
+			var err error
+			after, more, err = u.suppressSubscriptions(ctx, tx, suppression, from)
+
+			return err
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return suppression, nil
+}
+
+// createSuppression stores a suppression. The subscriptions it blocks are
+// stopped with suppressSubscriptions.
+func (u *Usecase) createSuppression(ctx context.Context, tx Transaction, suppression *domain.Suppression) error {
+	if err := tx.Suppressions().CreateSuppression(ctx, suppression); err != nil {
+		return err
+	}
+
+	event := &SuppressionAdded{
+		SuppressionPK:  suppression.PK.Bytes(),
+		OrganizationPK: suppression.OrganizationPK.Bytes(),
+		EmailAddress:   string(suppression.EmailAddress),
+		Domain:         suppression.Domain,
+		Reason:         string(suppression.Reason),
+	}
+
+	if !suppression.ExpiresAt.IsZero() {
+		event.ExpiresAt = timestamppb.New(suppression.ExpiresAt)
+	}
+
+	return enqueue(ctx, tx, event, suppressionEvent(suppression))
+}
+
+// suppressSubscriptions stops the next batch of subscriptions blocked by a
+// suppression, starting after the given primary key. It returns the primary
+// key of the last subscription of the batch and whether more may follow.
+func (u *Usecase) suppressSubscriptions(ctx context.Context, tx Transaction, suppression *domain.Suppression, after uuid.UUID) (uuid.UUID, bool, error) {
+	subscriptions, err := tx.Subscriptions().ListSuppressedSubscriptions(ctx, suppression, suppressibleStates, after, SuppressionBatchSize)
+	if err != nil {
+		return after, false, err
+	}
+
+	now := time.Now()
+
+	for _, subscription := range subscriptions {
+		after = subscription.PK
+
+		subscription, err = domain.SuppressSubscription(*subscription, now)
+		if err != nil {
+			return after, false, err
+		}
+
+		if err := saveSubscription(ctx, tx, subscription, &SubscriptionSuppressed{
+			SubscriptionPK: subscription.PK.Bytes(),
+			SubscriberPK:   subscription.SubscriberPK.Bytes(),
+			ListPK:         subscription.ListPK.Bytes(),
+			OrganizationPK: subscription.OrganizationPK.Bytes(),
+			SuppressionPK:  suppression.PK.Bytes(),
+		}); err != nil {
+			return after, false, err
+		}
+	}
+
+	return after, len(subscriptions) == SuppressionBatchSize, nil
+}
+
+func (u *Usecase) removeSuppression(ctx context.Context, orgPK, suppressionPK uuid.UUID) error {
+	return u.transaction(ctx, func(tx Transaction) error {
+		if err := tx.Suppressions().DeleteSuppression(ctx, orgPK, suppressionPK); err != nil {
+			return err
+		}
+
+		return enqueue(ctx, tx, &SuppressionRemoved{
+			SuppressionPK:  suppressionPK.Bytes(),
+			OrganizationPK: orgPK.Bytes(),
+		}, outbox.Metadata{
+			AggregateType:  AggregateSuppression,
+			AggregateID:    suppressionPK,
+			OrganizationPK: orgPK,
+		})
+	})
+}
+
+// checkSuppressions returns a SuppressedError when an email address is
+// suppressed within an organization.
+func checkSuppressions(ctx context.Context, tx Transaction, orgPK uuid.UUID, emailAddr domain.EmailAddress) error {
+	now := time.Now()
+
+	suppressions, err := tx.Suppressions().ListSuppressionsForEmailAddress(ctx, orgPK, emailAddr, now)
+	if err != nil {
+		return err
+	}
+
+	return domain.CheckSuppressions(emailAddr, suppressions, now)
+}
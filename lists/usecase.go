@@ -1,179 +1,699 @@
 package lists
 
 import (
-	"database/sql"
+	"context"
+	"errors"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/janartodesk/domain-design/lists/domain"
-	"github.com/janartodesk/domain-design/lists/model"
 	"github.com/janartodesk/domain-design/pkg/db"
+	"github.com/janartodesk/domain-design/pkg/outbox"
 	"github.com/uptrace/bun"
 	"google.golang.org/protobuf/proto"
 )
 
+// ConfirmationTTL is the time a subscriber has to confirm an opt-in.
+const ConfirmationTTL = 72 * time.Hour
+
+// defaultRetryPolicy is the retry policy of new Usecases.
+var defaultRetryPolicy = db.DefaultRetryPolicy()
+
+// EventPublisher publishes domain events relayed from the outbox. Events are
+// wrapped in an envelope carrying their metadata.
 type EventPublisher interface {
-	Publish(proto.Message) error
+	Publish(context.Context, *outbox.Envelope) error
 }
 
+// Usecase implements the subscriber list commands.
+//
+// Commands run in a unit of work, which is a database transaction unless the
+// Usecase is created with NewUsecaseWithUnitOfWork. Domain events are enqueued
+// within the unit of work of the command that raised them. In a database they
+// are written to the outbox; use NewRelay to deliver them to an EventPublisher.
+//
+// A command that fails for a concurrent command, with a version conflict or a
+// serialization failure, is run again in a new unit of work as Retry allows.
+// Only the events of the attempt that commits are published.
+//
+// Every method takes the context of the request it serves, which bounds its
+// queries and transaction. The trace ID, causation ID and actor the context
+// carries, as set with package request, are recorded on the events raised.
+//
+// Commands that change an existing entity on behalf of a client take the
+// version the client expects the entity to be at and fail with a
+// domain.VersionConflictError when it has moved on. domain.AnyVersion skips the
+// check. The changed entity is returned at its new version.
+//
+// Every command is scoped to an organization. Lists, subscribers and
+// subscriptions of other organizations are never read nor changed.
 type Usecase struct {
-	db     *bun.DB
-	events EventPublisher
+	db  *bun.DB
+	uow UnitOfWork
+
+	// MergeRule decides how subscription data is merged when a subscriber
+	// subscribes to a list they have a subscription to already.
+	MergeRule domain.DataMergeRule
+
+	// SoftBounceThreshold is the number of soft bounces after which a
+	// subscriber is treated as hard bounced. Zero means
+	// DefaultSoftBounceThreshold.
+	SoftBounceThreshold uint32
+
+	// Persistence selects how lists, subscribers and subscriptions are
+	// persisted. It must not change once a deployment has written data.
+	Persistence Persistence
+
+	// Retry re-runs commands that fail for a concurrent command. Retryable
+	// defaults to version conflicts other than those with the version
+	// expected by a client, and serialization failures. The zero value runs
+	// commands once.
+	Retry db.RetryPolicy
 }
 
-// CreateList creates a subscriber list.
-func (u *Usecase) CreateList(title string) (*domain.List, error) {
-	list, err := domain.CreateList(title)
+// NewUsecase creates a Usecase persisting to a database.
+func NewUsecase(db *bun.DB) *Usecase {
+	return &Usecase{
+		db:    db,
+		Retry: defaultRetryPolicy,
+	}
+}
+
+// NewUsecaseWithUnitOfWork creates a Usecase whose commands run in the given
+// unit of work. Persistence is ignored.
+//
+// Queries, exports, statistics and the event runtimes read the database
+// directly, so they are only available when a database is given as well.
+// Without one, queries, exports and statistics return ErrNoDatabase.
+func NewUsecaseWithUnitOfWork(db *bun.DB, uow UnitOfWork) *Usecase {
+	return &Usecase{
+		db:    db,
+		uow:   uow,
+		Retry: defaultRetryPolicy,
+	}
+}
+
+// NewRelay creates an outbox relay that delivers domain events to a publisher.
+func NewRelay(db *bun.DB, events EventPublisher) *outbox.Relay {
+	return outbox.NewRelay(db, events)
+}
+
+// CreateOrganization creates an organization.
+func (u *Usecase) CreateOrganization(ctx context.Context, name string) (*domain.Organization, error) {
+	org, err := domain.CreateOrganization(name)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := model.CreateList(u.db, list); err != nil {
+	if err := u.transaction(ctx, func(tx Transaction) error {
+		if err := tx.Organizations().CreateOrganization(ctx, org); err != nil {
+			return err
+		}
+
+		return enqueue(ctx, tx, &OrganizationCreated{
+			OrganizationPK: org.PK.Bytes(),
+			Name:           org.Name,
+		}, organizationEvent(org))
+	}); err != nil {
 		return nil, err
 	}
 
-	return list, nil
+	return org, nil
 }
 
-// RenameList renames a subscriber list.
-func (u *Usecase) RenameList(listPK uuid.UUID, title string) (*domain.List, error) {
-	list, err := model.GetList(u.db, listPK)
+// RenameOrganization renames an organization at the expected version.
+func (u *Usecase) RenameOrganization(ctx context.Context, orgPK uuid.UUID, name string, expectedVersion uint32) (*domain.Organization, error) {
+	var org *domain.Organization
+
+	err := u.transaction(ctx, func(tx Transaction) error {
+		previous, err := tx.Organizations().GetOrganization(ctx, orgPK)
+		if err != nil {
+			return err
+		}
+
+		if err := domain.CheckVersion(previous.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		org, err = domain.RenameOrganization(*previous, name)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Organizations().UpdateOrganization(ctx, org); err != nil {
+			return err
+		}
+
+		return enqueue(ctx, tx, &OrganizationRenamed{
+			OrganizationPK: org.PK.Bytes(),
+			Name:           org.Name,
+			PreviousName:   previous.Name,
+		}, organizationEvent(org))
+	})
+
 	if err != nil {
 		return nil, err
 	}
 
-	list, err = domain.RenameList(*list, title)
+	return org, nil
+}
+
+// CreateList creates a subscriber list.
+func (u *Usecase) CreateList(ctx context.Context, orgPK uuid.UUID, title string) (*domain.List, error) {
+	var list *domain.List
+
+	err := u.transaction(ctx, func(tx Transaction) error {
+		org, err := tx.Organizations().GetOrganization(ctx, orgPK)
+		if err != nil {
+			return err
+		}
+
+		list, err = domain.CreateList(*org, title)
+		if err != nil {
+			return err
+		}
+
+		return saveList(ctx, tx, list, &ListCreated{
+			ListPK:         list.PK.Bytes(),
+			OrganizationPK: list.OrganizationPK.Bytes(),
+			Title:          list.Title,
+		})
+	})
+
 	if err != nil {
 		return nil, err
 	}
 
-	if err := model.UpdateList(u.db, list); err != nil {
+	return list, nil
+}
+
+// RenameList renames a subscriber list at the expected version.
+func (u *Usecase) RenameList(ctx context.Context, orgPK, listPK uuid.UUID, title string, expectedVersion uint32) (*domain.List, error) {
+	var list *domain.List
+
+	err := u.transaction(ctx, func(tx Transaction) error {
+		previous, err := tx.Lists().GetList(ctx, orgPK, listPK)
+		if err != nil {
+			return err
+		}
+
+		if err := domain.CheckVersion(previous.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		list, err = domain.RenameList(*previous, title)
+		if err != nil {
+			return err
+		}
+
+		return saveList(ctx, tx, list, &ListRenamed{
+			ListPK:         list.PK.Bytes(),
+			OrganizationPK: list.OrganizationPK.Bytes(),
+			Title:          list.Title,
+			PreviousTitle:  previous.Title,
+		})
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
 	return list, nil
 }
 
-// DeleteList deletes a subscriber list.
-func (u *Usecase) DeleteList(listPK uuid.UUID) error {
-	if err := model.DeleteList(u.db, listPK); err != nil {
+// DeleteList deletes a subscriber list at the expected version.
+func (u *Usecase) DeleteList(ctx context.Context, orgPK, listPK uuid.UUID, expectedVersion uint32) error {
+	return u.transaction(ctx, func(tx Transaction) error {
+		list, err := tx.Lists().GetList(ctx, orgPK, listPK)
+		if err != nil {
+			return err
+		}
+
+		if err := domain.CheckVersion(list.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		return deleteList(ctx, tx, list, &ListDeleted{
+			ListPK:         list.PK.Bytes(),
+			OrganizationPK: list.OrganizationPK.Bytes(),
+		})
+	})
+}
+
+// SubscribeSubscriber subscribes a subscriber into a list. A subscriber who
+// unsubscribed from the list is resubscribed instead.
+func (u *Usecase) SubscribeSubscriber(ctx context.Context, orgPK, listPK uuid.UUID, emailAddr domain.EmailAddress, data domain.SubscriptionData) error {
+	return u.transaction(ctx, func(tx Transaction) error {
+		_, _, err := u.createSubscription(ctx, tx, orgPK, listPK, emailAddr, data, domain.SubscriptionActive)
+
 		return err
+	})
+}
+
+// Unsubscribe unsubscribes a subscriber from a list, cancelling the
+// subscription at the expected version.
+func (u *Usecase) Unsubscribe(ctx context.Context, orgPK, listPK, subscriptionPK uuid.UUID, expectedVersion uint32) (*domain.Subscription, error) {
+	var subscription *domain.Subscription
+
+	err := u.transaction(ctx, func(tx Transaction) error {
+		previous, err := tx.Subscriptions().GetSubscription(ctx, orgPK, listPK, subscriptionPK)
+		if err != nil {
+			return err
+		}
+
+		if err := domain.CheckVersion(previous.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		subscription, err = domain.CancelSubscription(*previous, time.Now())
+		if err != nil {
+			return err
+		}
+
+		return saveSubscription(ctx, tx, subscription, &SubscriberUnsubscribed{
+			SubscriptionPK: subscription.PK.Bytes(),
+			SubscriberPK:   subscription.SubscriberPK.Bytes(),
+			ListPK:         subscription.ListPK.Bytes(),
+			OrganizationPK: subscription.OrganizationPK.Bytes(),
+			PreviousState:  string(previous.State),
+		})
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	return u.events.Publish(&ListDeleted{
-		ListPK: listPK.Bytes(),
+	return subscription, nil
+}
+
+// ChangeSubscriptionData merges new data into the data of an active or pending
+// subscription at the expected version, according to the MergeRule.
+func (u *Usecase) ChangeSubscriptionData(ctx context.Context, orgPK, listPK, subscriptionPK uuid.UUID, data domain.SubscriptionData, expectedVersion uint32) (*domain.Subscription, error) {
+	var subscription *domain.Subscription
+
+	err := u.transaction(ctx, func(tx Transaction) error {
+		list, err := tx.Lists().GetList(ctx, orgPK, listPK)
+		if err != nil {
+			return err
+		}
+
+		previous, err := tx.Subscriptions().GetSubscription(ctx, orgPK, listPK, subscriptionPK)
+		if err != nil {
+			return err
+		}
+
+		if err := domain.CheckVersion(previous.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		subscription, err = domain.ChangeSubscriptionData(*previous, *list, data, u.MergeRule)
+		if err != nil {
+			return err
+		}
+
+		event, err := newSubscriptionDataChanged(subscription)
+		if err != nil {
+			return err
+		}
+
+		return saveSubscription(ctx, tx, subscription, event)
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
 }
 
-// SubscribeSubscriber subscribes a subscriber into a list.
-func (u *Usecase) SubscribeSubscriber(listPK uuid.UUID, emailAddr domain.EmailAddress, data domain.SubscriptionData) error {
-	return db.WithTransaction(u.db, func(tx bun.Tx) error {
-		if _, err := u.createSubscription(tx, listPK, emailAddr, data); err != nil {
+// OptInSubscriber opts a subscriber into a list.
+//
+// The subscription remains pending until it is confirmed with the returned
+// token using ConfirmSubscription.
+func (u *Usecase) OptInSubscriber(ctx context.Context, orgPK, listPK uuid.UUID, emailAddr domain.EmailAddress, data domain.SubscriptionData) (string, error) {
+	var token string
+
+	err := u.transaction(ctx, func(tx Transaction) error {
+		subscription, previous, err := u.createSubscription(ctx, tx, orgPK, listPK, emailAddr, data, domain.SubscriptionPending)
+		if err != nil {
 			return err
 		}
 
+		var (
+			isResubscription bool
+			previousState    domain.SubscriptionState
+		)
+
+		if previous != nil {
+			isResubscription = previous.State == domain.SubscriptionUnsubscribed
+			previousState = previous.State
+
+			// Opting in again while pending replaces the confirmation, which
+			// must still tell what the subscription was before the first opt-in.
+			if previous.State == domain.SubscriptionPending {
+				isResubscription, previousState = false, ""
+
+				replaced, err := tx.Confirmations().GetConfirmationForSubscription(ctx, orgPK, subscription.PK)
+				if err != nil && !errors.Is(err, domain.ErrNotFound) {
+					return err
+				}
+
+				if replaced != nil {
+					isResubscription, previousState = replaced.IsResubscription, replaced.PreviousState
+				}
+			}
+
+			if err := tx.Confirmations().DeleteConfirmationsForSubscription(ctx, orgPK, subscription.PK); err != nil {
+				return err
+			}
+		}
+
+		confirmation, err := domain.CreateConfirmation(*subscription, isResubscription, previousState, ConfirmationTTL)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Confirmations().CreateConfirmation(ctx, confirmation); err != nil {
+			return err
+		}
+
+		token = confirmation.Token
+
 		return nil
 	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
 }
 
-// UnsubscribeSubscriber unsubscribes a subscriber from a list.
-func (u *Usecase) Unsubscribe(listPK, subscriptionPK uuid.UUID) error {
-	return db.WithTransaction(u.db, func(tx bun.Tx) error {
-		subscription, err := model.GetSubscription(tx, listPK, subscriptionPK)
+// ConfirmSubscription confirms a pending subscription with the token returned
+// by OptInSubscriber.
+func (u *Usecase) ConfirmSubscription(ctx context.Context, orgPK uuid.UUID, token string) error {
+	return u.transaction(ctx, func(tx Transaction) error {
+		confirmation, err := tx.Confirmations().GetConfirmationByToken(ctx, orgPK, token)
+		if err != nil {
+			return err
+		}
+
+		subscription, err := tx.Subscriptions().GetSubscription(ctx, orgPK, confirmation.ListPK, confirmation.SubscriptionPK)
+		if err != nil {
+			return err
+		}
+
+		subscription, err = domain.ConfirmSubscription(*subscription, *confirmation, time.Now())
 		if err != nil {
 			return err
 		}
 
-		if _, err := u.cancelSubscription(tx, subscription); err != nil {
+		if err := tx.Confirmations().DeleteConfirmation(ctx, confirmation); err != nil {
+			return err
+		}
+
+		if confirmation.IsResubscription {
+			event, err := newSubscriberResubscribed(subscription)
+			if err != nil {
+				return err
+			}
+
+			return saveSubscription(ctx, tx, subscription, event)
+		}
+
+		return saveSubscription(ctx, tx, subscription, &SubscriberOptedIn{
+			SubscriberPK:   subscription.SubscriberPK.Bytes(),
+			ListPK:         subscription.ListPK.Bytes(),
+			OrganizationPK: subscription.OrganizationPK.Bytes(),
+		})
+	})
+}
+
+// ExpireConfirmations removes up to limit confirmations that expired before
+// they were confirmed, along with their pending subscriptions, and returns the
+// number of confirmations removed.
+//
+// It is a maintenance job spanning every organization.
+func (u *Usecase) ExpireConfirmations(ctx context.Context, limit uint32) (int, error) {
+	var n int
+
+	err := u.transaction(ctx, func(tx Transaction) error {
+		now := time.Now()
+
+		confirmations, err := tx.Confirmations().ListExpiredConfirmations(ctx, now, limit)
+		if err != nil {
 			return err
 		}
 
+		for _, confirmation := range confirmations {
+			if err := u.expireConfirmation(ctx, tx, confirmation, now); err != nil {
+				return err
+			}
+		}
+
+		n = len(confirmations)
+
 		return nil
 	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
 }
 
-// OptInSubscriber opts a subscriber into a list.
-func (u *Usecase) OptInSubscriber(listPK uuid.UUID, emailAddr domain.EmailAddress, data domain.SubscriptionData) error {
-	return db.WithTransaction(u.db, func(tx bun.Tx) error {
-		subscription, err := u.createSubscription(tx, listPK, emailAddr, data)
+// OptOutSubscriber opts a subscriber out from a list, cancelling their
+// subscription at the expected version.
+func (u *Usecase) OptOutSubscriber(ctx context.Context, orgPK, listPK, subscriberPK uuid.UUID, expectedVersion uint32) (*domain.Subscription, error) {
+	var subscription *domain.Subscription
+
+	err := u.transaction(ctx, func(tx Transaction) error {
+		previous, err := tx.Subscriptions().GetSubscriptionForSubscriber(ctx, orgPK, listPK, subscriberPK)
+		if err != nil {
+			return err
+		}
+
+		if err := domain.CheckVersion(previous.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		subscription, err = domain.CancelSubscription(*previous, time.Now())
 		if err != nil {
 			return err
 		}
 
-		return u.events.Publish(&SubscriberOptedIn{
-			SubscriberPK: subscription.SubscriberPK.Bytes(),
-			ListPK:       subscription.ListPK.Bytes(),
+		return saveSubscription(ctx, tx, subscription, &SubscriberOptedOut{
+			SubscriberPK:   subscription.SubscriberPK.Bytes(),
+			ListPK:         subscription.ListPK.Bytes(),
+			OrganizationPK: subscription.OrganizationPK.Bytes(),
 		})
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
 }
 
-// OptOutSubscriber opts a subscriber out from a list.
-func (u *Usecase) OptOutSubscriber(listPK, subscriberPK uuid.UUID) error {
-	return db.WithTransaction(u.db, func(tx bun.Tx) error {
-		subscription, err := model.GetSubscriptionForSubscriber(tx, listPK, subscriberPK)
+// ForgetSubscriber erases the personal data of a subscriber.
+//
+// The subscriber's email address is replaced with a tombstone, the email
+// address and data of all of their subscriptions are scrubbed and the
+// subscriptions are cancelled. The events the subscriber and their
+// subscriptions raised are redacted alike wherever they are kept, and the
+// records of feedback reports about the email address are deleted.
+// Suppressions of the email address are kept, so that it is not mailed again.
+func (u *Usecase) ForgetSubscriber(ctx context.Context, orgPK, subscriberPK uuid.UUID) error {
+	return u.transaction(ctx, func(tx Transaction) error {
+		subscriber, err := tx.Subscribers().GetSubscriber(ctx, orgPK, subscriberPK)
 		if err != nil {
 			return err
 		}
 
-		subscription, err = u.cancelSubscription(tx, subscription)
+		if err := tx.Feedback().DeleteReportsForEmailAddress(ctx, orgPK, subscriber.EmailAddress); err != nil {
+			return err
+		}
+
+		subscriber, err = domain.ForgetSubscriber(*subscriber)
 		if err != nil {
 			return err
 		}
 
-		return u.events.Publish(&SubscriberOptedOut{
-			SubscriberPK: subscription.SubscriberPK.Bytes(),
-			ListPK:       subscription.ListPK.Bytes(),
-		})
+		if err := saveSubscriber(ctx, tx, subscriber, &SubscriberForgotten{
+			SubscriberPK:   subscriber.PK.Bytes(),
+			OrganizationPK: subscriber.OrganizationPK.Bytes(),
+		}); err != nil {
+			return err
+		}
+
+		subscriptions, err := tx.Subscriptions().ListSubscriptionsForSubscriber(ctx, orgPK, subscriberPK)
+		if err != nil {
+			return err
+		}
+
+		redact := redactSubscriber(subscriber)
+
+		if err := tx.Redact(ctx, AggregateSubscriber, subscriber.PK, redact); err != nil {
+			return err
+		}
+
+		for _, previous := range subscriptions {
+			if err := tx.Redact(ctx, AggregateSubscription, previous.PK, redact); err != nil {
+				return err
+			}
+
+			if previous.State == domain.SubscriptionForgotten {
+				continue
+			}
+
+			subscription, err := domain.ForgetSubscription(*previous, *subscriber, time.Now())
+			if err != nil {
+				return err
+			}
+
+			if err := saveSubscription(ctx, tx, subscription, &SubscriptionForgotten{
+				SubscriptionPK: subscription.PK.Bytes(),
+				SubscriberPK:   subscription.SubscriberPK.Bytes(),
+				ListPK:         subscription.ListPK.Bytes(),
+				OrganizationPK: subscription.OrganizationPK.Bytes(),
+				PreviousState:  string(previous.State),
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	})
 }
 
-func (u *Usecase) createSubscription(tx bun.IDB, listPK uuid.UUID, emailAddr domain.EmailAddress, data domain.SubscriptionData) (*domain.Subscription, error) {
-	if err := emailAddr.Validate(); err != nil {
-		return nil, err
+// createSubscription subscribes a subscriber to a list in the given state and
+// raises SubscriberSubscribed, or SubscriberResubscribed when an unsubscribed
+// subscriber subscribes again.
+//
+// Suppressed email addresses are refused with a domain.SuppressedError. A
+// subscriber's existing subscription to the list is reactivated rather than
+// duplicated, in which case its state before reactivation is returned as well.
+func (u *Usecase) createSubscription(
+	ctx context.Context,
+	tx Transaction,
+	orgPK, listPK uuid.UUID,
+	emailAddr domain.EmailAddress,
+	data domain.SubscriptionData,
+	state domain.SubscriptionState,
+) (*domain.Subscription, *domain.Subscription, error) {
+	emailAddr, err := domain.ParseEmailAddress(string(emailAddr))
+	if err != nil {
+		return nil, nil, err
 	}
 
-	list, err := model.GetList(tx, listPK)
+	org, err := tx.Organizations().GetOrganization(ctx, orgPK)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	list, err := tx.Lists().GetList(ctx, org.PK, listPK)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := checkSuppressions(ctx, tx, org.PK, emailAddr); err != nil {
+		return nil, nil, err
 	}
 
-	subscriber, err := model.GetSubscriberByEmailAddress(tx, emailAddr)
+	subscriber, err := tx.Subscribers().GetSubscriberByEmailAddress(ctx, org.PK, emailAddr)
 	if err != nil {
-		switch err {
-		case sql.ErrNoRows:
-			subscriber, err := domain.CreateSubscriber(emailAddr)
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			subscriber, err = domain.CreateSubscriber(*org, emailAddr)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
-			if err := model.CreateSubscriber(tx, subscriber); err != nil {
-				return nil, err
+			if err := saveSubscriber(ctx, tx, subscriber, newSubscriberCreated(subscriber)); err != nil {
+				return nil, nil, err
 			}
 		default:
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
-	subscription, err := domain.CreateSubscription(*subscriber, *list, data)
+	previous, err := tx.Subscriptions().GetSubscriptionForSubscriber(ctx, org.PK, list.PK, subscriber.PK)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, nil, err
+	}
+
+	var subscription *domain.Subscription
+
+	if previous != nil {
+		subscription, err = domain.ResubscribeSubscription(*previous, *list, state, data, u.MergeRule, time.Now())
+	} else if state == domain.SubscriptionPending {
+		subscription, err = domain.CreatePendingSubscription(*subscriber, *list, data)
+	} else {
+		subscription, err = domain.CreateSubscription(*subscriber, *list, data)
+	}
+
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	if err := model.CreateSubscription(tx, subscription); err != nil {
-		return nil, err
+	var event proto.Message
+
+	// Subscribing again after unsubscribing is a resubscription, while other
+	// reactivations are reported as subscriptions from their previous state.
+	if previous != nil && previous.State == domain.SubscriptionUnsubscribed && subscription.State == domain.SubscriptionActive {
+		event, err = newSubscriberResubscribed(subscription)
+	} else {
+		event, err = newSubscriberSubscribed(subscription, previous)
 	}
 
-	return subscription, nil
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := saveSubscription(ctx, tx, subscription, event); err != nil {
+		return nil, nil, err
+	}
+
+	return subscription, previous, nil
 }
 
-func (u *Usecase) cancelSubscription(tx bun.IDB, subscription *domain.Subscription) (*domain.Subscription, error) {
-	subscription, err := domain.CancelSubscription(*subscription)
+func (u *Usecase) expireConfirmation(ctx context.Context, tx Transaction, confirmation *domain.Confirmation, now time.Time) error {
+	if err := tx.Confirmations().DeleteConfirmation(ctx, confirmation); err != nil {
+		return err
+	}
+
+	subscription, err := tx.Subscriptions().GetSubscription(ctx, confirmation.OrganizationPK, confirmation.ListPK, confirmation.SubscriptionPK)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+
+		return err
 	}
 
-	if err := model.UpdateSubscription(tx, subscription); err != nil {
-		return nil, err
+	if subscription.State != domain.SubscriptionPending {
+		return nil
 	}
 
-	return subscription, nil
+	subscription, err = domain.ExpireSubscription(*subscription, *confirmation, now)
+	if err != nil {
+		return err
+	}
+
+	event := &SubscriptionExpired{
+		SubscriptionPK: subscription.PK.Bytes(),
+		SubscriberPK:   subscription.SubscriberPK.Bytes(),
+		ListPK:         subscription.ListPK.Bytes(),
+		OrganizationPK: subscription.OrganizationPK.Bytes(),
+	}
+
+	if subscription.State != domain.SubscriptionPending {
+		event.State = string(subscription.State)
+
+		return saveSubscription(ctx, tx, subscription, event)
+	}
+
+	return deleteSubscription(ctx, tx, subscription, event)
 }
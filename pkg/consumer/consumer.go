@@ -0,0 +1,221 @@
+// Package consumer delivers enveloped events to the handlers registered for
+// their payload types.
+//
+// Each event is handled at most once per consumer, as the event IDs of handled
+// events are recorded in an inbox table. Failing handlers are retried with
+// exponential backoff, and events that keep failing are parked in a
+// dead-letter table from which they can be replayed.
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/pkg/db"
+	"github.com/janartodesk/domain-design/pkg/outbox"
+	"github.com/janartodesk/domain-design/pkg/request"
+	"github.com/uptrace/bun"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = 100 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+)
+
+// HandlerFunc handles the payload of an event. The envelope of the event and
+// the transaction it is handled in are available from the context, which
+// carries the request values of the event as well.
+type HandlerFunc func(ctx context.Context, event proto.Message) error
+
+// InboxMessage is a database model for an event handled by a consumer.
+type InboxMessage struct {
+	Consumer  string    `bun:"consumer,pk"`
+	EventID   uuid.UUID `bun:"event_id,pk"`
+	Type      string    `bun:"type"`
+	HandledAt time.Time `bun:"handled_at"`
+
+	bun.BaseModel `bun:"inbox"`
+}
+
+// Consumer dispatches events to handlers registered per payload type.
+//
+// Consumer implements outbox.Publisher, so it can be fed by an outbox relay
+// directly. Events without a registered handler are ignored.
+type Consumer struct {
+	db       *bun.DB
+	name     string
+	handlers map[protoreflect.FullName]HandlerFunc
+
+	// MaxAttempts is the number of times a handler is run before its event
+	// is dead-lettered.
+	MaxAttempts    uint32
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// NewConsumer creates a consumer. The name identifies the consumer in the
+// inbox and dead-letter tables and must be stable across deployments.
+func NewConsumer(db *bun.DB, name string) *Consumer {
+	return &Consumer{
+		db:             db,
+		name:           name,
+		handlers:       map[protoreflect.FullName]HandlerFunc{},
+		MaxAttempts:    defaultMaxAttempts,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+	}
+}
+
+// Handle registers the handler of events of the type of event, replacing any
+// handler registered for it before.
+func (c *Consumer) Handle(event proto.Message, handler HandlerFunc) {
+	c.handlers[event.ProtoReflect().Descriptor().FullName()] = handler
+}
+
+// Publish consumes an event. It implements outbox.Publisher.
+func (c *Consumer) Publish(ctx context.Context, env *outbox.Envelope) error {
+	return c.Consume(ctx, env)
+}
+
+// Consume hands an event to its handler, retrying with exponential backoff
+// while the handler fails.
+//
+// An event that has been handled before is skipped. An event whose handler
+// fails MaxAttempts times, or whose payload cannot be decoded, is
+// dead-lettered. The returned error is only ever one of dead-lettering the
+// event or of the context.
+func (c *Consumer) Consume(ctx context.Context, env *outbox.Envelope) error {
+	handler, ok := c.handlers[env.GetPayload().MessageName()]
+	if !ok {
+		return nil
+	}
+
+	var (
+		attempts uint32
+		err      error
+	)
+
+	for {
+		attempts++
+
+		if err = c.handle(ctx, env, handler); err == nil {
+			return nil
+		}
+
+		if _, ok := err.(*decodeError); ok || attempts >= c.MaxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.backoff(attempts)):
+		}
+	}
+
+	return c.deadLetter(ctx, env, attempts, err)
+}
+
+// handle runs the handler of an event in a transaction that records the event
+// in the inbox.
+func (c *Consumer) handle(ctx context.Context, env *outbox.Envelope, handler HandlerFunc) error {
+	event, err := outbox.Unwrap(env)
+	if err != nil {
+		return &decodeError{err}
+	}
+
+	ctx = withRequest(ctx, env)
+
+	return db.WithTransaction(ctx, c.db, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewInsert().Model(&InboxMessage{
+			Consumer:  c.name,
+			EventID:   uuid.FromBytesOrNil(env.GetEventID()),
+			Type:      string(env.GetPayload().MessageName()),
+			HandledAt: time.Now(),
+		}).On("CONFLICT DO NOTHING").Exec(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return nil
+		}
+
+		return handler(withEnvelope(withTx(ctx, tx), env), event)
+	})
+}
+
+func (c *Consumer) backoff(attempts uint32) time.Duration {
+	d := c.InitialBackoff
+
+	for i := uint32(1); i < attempts && d < c.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	if d > c.MaxBackoff {
+		d = c.MaxBackoff
+	}
+
+	return d
+}
+
+// decodeError is returned for events whose payload cannot be decoded, which
+// retrying cannot fix.
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string {
+	return "decoding event: " + e.err.Error()
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+type contextKey int
+
+const (
+	envelopeKey contextKey = iota
+	txKey
+)
+
+// withRequest returns a context for handling an event on behalf of the request
+// that raised it, with the event as the cause of what the handler does.
+func withRequest(ctx context.Context, env *outbox.Envelope) context.Context {
+	ctx = request.WithActor(ctx, env.GetActor())
+	ctx = request.WithTraceID(ctx, env.GetCorrelationID())
+
+	return request.WithCausationID(ctx, uuid.FromBytesOrNil(env.GetEventID()).String())
+}
+
+func withEnvelope(ctx context.Context, env *outbox.Envelope) context.Context {
+	return context.WithValue(ctx, envelopeKey, env)
+}
+
+func withTx(ctx context.Context, tx bun.IDB) context.Context {
+	return context.WithValue(ctx, txKey, tx)
+}
+
+// EnvelopeFromContext returns the envelope of the event being handled.
+func EnvelopeFromContext(ctx context.Context) (*outbox.Envelope, bool) {
+	env, ok := ctx.Value(envelopeKey).(*outbox.Envelope)
+
+	return env, ok
+}
+
+// TxFromContext returns the transaction the event is handled in. Changes
+// written through it are committed together with the inbox record of the
+// event, so they are applied exactly once.
+func TxFromContext(ctx context.Context) (bun.IDB, bool) {
+	tx, ok := ctx.Value(txKey).(bun.IDB)
+
+	return tx, ok
+}
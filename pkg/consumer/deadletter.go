@@ -0,0 +1,209 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/pkg/outbox"
+	"github.com/uptrace/bun"
+	"google.golang.org/protobuf/proto"
+)
+
+// DeadLetter is a database model for an event a consumer failed to handle.
+type DeadLetter struct {
+	PK        uuid.UUID `bun:"pk,pk"`
+	Consumer  string    `bun:"consumer"`
+	EventID   uuid.UUID `bun:"event_id"`
+	Type      string    `bun:"type"`
+	Payload   []byte    `bun:"payload"`
+	Attempts  uint32    `bun:"attempts"`
+	LastError string    `bun:"last_error"`
+	FailedAt  time.Time `bun:"failed_at"`
+
+	bun.BaseModel `bun:"dead_letters"`
+}
+
+// Decode decodes the envelope of the dead-lettered event.
+func (m *DeadLetter) Decode() (*outbox.Envelope, error) {
+	env := &outbox.Envelope{}
+
+	if err := proto.Unmarshal(m.Payload, env); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+func (c *Consumer) deadLetter(ctx context.Context, env *outbox.Envelope, attempts uint32, cause error) error {
+	payload, err := proto.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.db.NewInsert().Model(&DeadLetter{
+		PK:        uuid.Must(uuid.NewV4()),
+		Consumer:  c.name,
+		EventID:   uuid.FromBytesOrNil(env.GetEventID()),
+		Type:      string(env.GetPayload().MessageName()),
+		Payload:   payload,
+		Attempts:  attempts,
+		LastError: cause.Error(),
+		FailedAt:  time.Now(),
+	}).Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RedactDeadLetters rewrites the dead-lettered events of every consumer that an
+// aggregate raised with redact, so that data that must not be kept is erased
+// along with the outbox messages the events were relayed from.
+func RedactDeadLetters(ctx context.Context, db bun.IDB, aggregateType string, aggregateID uuid.UUID, redact outbox.RedactFunc) error {
+	letters := []DeadLetter{}
+
+	if err := db.NewSelect().Model(&letters).Where(
+		"event_id IN (?)",
+		db.NewSelect().Model((*outbox.Message)(nil)).Column("event_id").Where(
+			"aggregate_type = ? AND aggregate_id = ?",
+			aggregateType,
+			aggregateID,
+		),
+	).Scan(ctx); err != nil {
+		return err
+	}
+
+	for i := range letters {
+		letter := &letters[i]
+
+		env, err := letter.Decode()
+		if err != nil {
+			return err
+		}
+
+		if ok, err := outbox.RedactEnvelope(env, redact); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+
+		if letter.Payload, err = proto.Marshal(env); err != nil {
+			return err
+		}
+
+		if _, err := db.NewUpdate().Model(letter).Column("payload").WherePK().Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeadLetters returns up to limit dead-lettered events of the consumer, oldest
+// first.
+func (c *Consumer) DeadLetters(ctx context.Context, limit int) ([]DeadLetter, error) {
+	res := []DeadLetter{}
+
+	if err := c.db.NewSelect().Model(&res).
+		Where("consumer = ?", c.name).
+		Order("failed_at ASC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Replay hands a dead-lettered event to its handler once more.
+//
+// The dead letter is removed when the event is handled. Otherwise its attempts
+// and last error are updated and the handler's error is returned.
+func (c *Consumer) Replay(ctx context.Context, pk uuid.UUID) error {
+	letter := DeadLetter{
+		PK: pk,
+	}
+
+	if err := c.db.NewSelect().Model(&letter).WherePK().Where(
+		"consumer = ?",
+		c.name,
+	).Scan(ctx); err != nil {
+		return err
+	}
+
+	return c.replay(ctx, &letter)
+}
+
+// ReplayAll replays every dead-lettered event of the consumer, oldest first,
+// and returns the number of events handled.
+//
+// The returned error is the first error an event failed with. Replaying
+// continues with the next event regardless.
+func (c *Consumer) ReplayAll(ctx context.Context) (int, error) {
+	letters := []DeadLetter{}
+
+	if err := c.db.NewSelect().Model(&letters).
+		Where("consumer = ?", c.name).
+		Order("failed_at ASC").
+		Scan(ctx); err != nil {
+		return 0, err
+	}
+
+	var (
+		replayed int
+		firstErr error
+	)
+
+	for i := range letters {
+		if err := ctx.Err(); err != nil {
+			return replayed, err
+		}
+
+		if err := c.replay(ctx, &letters[i]); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			continue
+		}
+
+		replayed++
+	}
+
+	return replayed, firstErr
+}
+
+func (c *Consumer) replay(ctx context.Context, letter *DeadLetter) error {
+	env, err := letter.Decode()
+	if err != nil {
+		return err
+	}
+
+	var handleErr error
+
+	if handler, ok := c.handlers[env.GetPayload().MessageName()]; ok {
+		handleErr = c.handle(ctx, env, handler)
+	}
+
+	if handleErr == nil {
+		if _, err := c.db.NewDelete().Model(letter).WherePK().Exec(ctx); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	letter.Attempts++
+	letter.LastError = handleErr.Error()
+	letter.FailedAt = time.Now()
+
+	if _, err := c.db.NewUpdate().Model(letter).
+		Column("attempts", "last_error", "failed_at").
+		WherePK().
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	return handleErr
+}
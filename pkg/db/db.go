@@ -1,24 +1,218 @@
 package db
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+
 	"github.com/pkg/errors"
 	"github.com/uptrace/bun"
 )
 
-// WithTransaction runs a callback within a database transaction.
-func WithTransaction(db *bun.DB, callback func(bun.Tx) error) error {
-	tx, err := db.Begin()
+// TxOption configures the transaction started by WithTransaction.
+type TxOption func(*sql.TxOptions)
+
+// Isolation sets the isolation level of a transaction.
+func Isolation(level sql.IsolationLevel) TxOption {
+	return func(opts *sql.TxOptions) {
+		opts.Isolation = level
+	}
+}
+
+// ReadOnly makes a transaction read-only.
+func ReadOnly() TxOption {
+	return func(opts *sql.TxOptions) {
+		opts.ReadOnly = true
+	}
+}
+
+type scopeKey struct{}
+
+// scope is a transaction or a savepoint within one. The hooks of a savepoint
+// are handed to its parent when the savepoint is released.
+type scope struct {
+	db         *bun.DB
+	tx         bun.Tx
+	parent     *scope
+	savepoints int
+
+	afterCommit   []func()
+	afterRollback []func()
+}
+
+// WithTransaction runs a callback within a database transaction, which is
+// committed when the callback returns nil and rolled back otherwise. The
+// transaction is rolled back when the context is cancelled before it commits,
+// and when the callback panics, after which the panic continues.
+//
+// The callback is given a context carrying the transaction. Called with that
+// context, WithTransaction runs the callback within a savepoint of the
+// transaction instead, so that only the changes of the callback are rolled
+// back when it fails. Options apply to the outermost transaction only.
+func WithTransaction(ctx context.Context, db *bun.DB, callback func(context.Context, bun.Tx) error, opts ...TxOption) error {
+	if parent, ok := ctx.Value(scopeKey{}).(*scope); ok && parent.db == db {
+		return withSavepoint(ctx, parent, callback)
+	}
+
+	txOpts := &sql.TxOptions{}
+	for _, opt := range opts {
+		opt(txOpts)
+	}
+
+	tx, err := db.BeginTx(ctx, txOpts)
 	if err != nil {
 		return err
 	}
 
-	if err := callback(tx); err != nil {
+	s := &scope{
+		db: db,
+		tx: tx,
+	}
+
+	returned := false
+
+	defer func() {
+		if returned {
+			return
+		}
+
+		if p := recover(); p != nil {
+			tx.Rollback() //nolint:errcheck
+			s.rolledBack()
+
+			panic(p)
+		}
+	}()
+
+	err = callback(context.WithValue(ctx, scopeKey{}, s), tx)
+	returned = true
+
+	if err != nil {
 		if txErr := tx.Rollback(); txErr != nil {
-			errors.Wrap(err, txErr.Error())
+			err = errors.WithMessagef(err, "rollback: %v", txErr)
+		}
+
+		s.rolledBack()
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.rolledBack()
+
+		return err
+	}
+
+	for _, fn := range s.afterCommit {
+		fn()
+	}
+
+	return nil
+}
+
+// withSavepoint runs a callback within a savepoint of a transaction.
+func withSavepoint(ctx context.Context, parent *scope, callback func(context.Context, bun.Tx) error) error {
+	root := parent
+	for root.parent != nil {
+		root = root.parent
+	}
+
+	root.savepoints++
+	name := fmt.Sprintf("sp_%d", root.savepoints)
+
+	if _, err := parent.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+
+	s := &scope{
+		db:     parent.db,
+		tx:     parent.tx,
+		parent: parent,
+	}
+
+	rollback := func() error {
+		_, err := parent.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+		s.rolledBack()
+
+		return err
+	}
+
+	returned := false
+
+	defer func() {
+		if returned {
+			return
+		}
+
+		if p := recover(); p != nil {
+			rollback() //nolint:errcheck
+
+			panic(p)
+		}
+	}()
+
+	err := callback(context.WithValue(ctx, scopeKey{}, s), parent.tx)
+	returned = true
+
+	if err != nil {
+		if txErr := rollback(); txErr != nil {
+			err = errors.WithMessagef(err, "rollback to savepoint: %v", txErr)
 		}
 
 		return err
 	}
 
-	return tx.Commit()
+	if _, err := parent.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return err
+	}
+
+	parent.afterCommit = append(parent.afterCommit, s.afterCommit...)
+	parent.afterRollback = append(parent.afterRollback, s.afterRollback...)
+
+	return nil
+}
+
+// rolledBack runs the after-rollback hooks of a scope, latest first.
+func (s *scope) rolledBack() {
+	for i := len(s.afterRollback) - 1; i >= 0; i-- {
+		s.afterRollback[i]()
+	}
+}
+
+// InTransaction reports whether the context carries a transaction of db, in
+// which WithTransaction runs callbacks within savepoints. Such callbacks cannot
+// be retried on their own, as only the outermost transaction can be.
+func InTransaction(ctx context.Context, db *bun.DB) bool {
+	s, ok := ctx.Value(scopeKey{}).(*scope)
+
+	return ok && s.db == db
+}
+
+// AfterCommit registers fn to run once the transaction carried by the context
+// commits. Registered within a savepoint that is rolled back, fn never runs.
+// Without a transaction, fn runs right away.
+func AfterCommit(ctx context.Context, fn func()) {
+	s, ok := ctx.Value(scopeKey{}).(*scope)
+	if !ok {
+		fn()
+
+		return
+	}
+
+	s.afterCommit = append(s.afterCommit, fn)
+}
+
+// AfterRollback registers fn to run once the transaction carried by the
+// context, or the savepoint it was registered within, is rolled back. Without
+// a transaction, fn never runs.
+func AfterRollback(ctx context.Context, fn func()) {
+	if s, ok := ctx.Value(scopeKey{}).(*scope); ok {
+		s.afterRollback = append(s.afterRollback, fn)
+	}
+}
+
+// WithSnapshot runs a callback within a read-only repeatable read transaction,
+// which gives the callback a consistent view of the database.
+func WithSnapshot(ctx context.Context, db *bun.DB, callback func(context.Context, bun.Tx) error) error {
+	return WithTransaction(ctx, db, callback, Isolation(sql.LevelRepeatableRead), ReadOnly())
 }
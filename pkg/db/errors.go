@@ -0,0 +1,51 @@
+package db
+
+import (
+	"errors"
+)
+
+// SQLSTATEs of PostgreSQL errors.
+const (
+	uniqueViolation      = "23505"
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// IsSerializationFailure reports whether an error of the pgdriver or pgx
+// drivers is PostgreSQL aborting a transaction for a serialization failure or
+// a deadlock.
+func IsSerializationFailure(err error) bool {
+	switch sqlState(err) {
+	case serializationFailure, deadlockDetected:
+		return true
+	}
+
+	return false
+}
+
+// IsUniqueViolation reports whether an error of the pgdriver or pgx drivers is
+// a unique constraint violation.
+func IsUniqueViolation(err error) bool {
+	return sqlState(err) == uniqueViolation
+}
+
+// sqlState returns the SQLSTATE of an error of the pgdriver or pgx drivers.
+func sqlState(err error) string {
+	var pgxErr interface {
+		SQLState() string
+	}
+
+	if errors.As(err, &pgxErr) {
+		return pgxErr.SQLState()
+	}
+
+	var pgdriverErr interface {
+		Field(byte) string
+	}
+
+	if errors.As(err, &pgdriverErr) {
+		return pgdriverErr.Field('C')
+	}
+
+	return ""
+}
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+// RetryMetrics records the attempts of retried operations.
+type RetryMetrics interface {
+	// ObserveAttempt records the outcome of an attempt, counted from one. A
+	// nil error is a success. Retried reports whether a failed attempt is
+	// followed by another one.
+	ObserveAttempt(attempt int, err error, retried bool)
+}
+
+// RetryPolicy re-runs operations that fail with transient errors, waiting a
+// jittered exponential backoff between attempts.
+type RetryPolicy struct {
+	// MaxAttempts bounds the number of attempts, including the first one.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Retryable reports whether an error is transient. It defaults to
+	// IsSerializationFailure.
+	Retryable func(error) bool
+	Metrics   RetryMetrics
+}
+
+// DefaultRetryPolicy returns a policy making up to five attempts.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	}
+}
+
+// Do runs fn until it succeeds, fails with an error that is not retryable or
+// has been attempted MaxAttempts times, and returns the error of the last
+// attempt. Waiting for the next attempt stops when the context is cancelled.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) error {
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = IsSerializationFailure
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		retry := err != nil && attempt < p.MaxAttempts && retryable(err)
+
+		if p.Metrics != nil {
+			p.Metrics.ObserveAttempt(attempt, err, retry)
+		}
+
+		if !retry {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(p.backoff(attempt)):
+		}
+	}
+}
+
+// backoff returns a random wait of up to the exponential backoff after an
+// attempt, so that conflicting operations spread their next attempts.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	if d <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(d))) + 1
+}
+
+// RetryStats counts the attempts of retried operations. It implements
+// RetryMetrics and is safe for concurrent use.
+type RetryStats struct {
+	attempts  uint64
+	retries   uint64
+	successes uint64
+	failures  uint64
+}
+
+// RetryCounts are the counts of RetryStats.
+type RetryCounts struct {
+	Attempts uint64
+	// Retries counts failed attempts followed by another attempt.
+	Retries   uint64
+	Successes uint64
+	// Failures counts operations given up on.
+	Failures uint64
+}
+
+// ObserveAttempt counts an attempt.
+func (s *RetryStats) ObserveAttempt(attempt int, err error, retried bool) {
+	atomic.AddUint64(&s.attempts, 1)
+
+	switch {
+	case err == nil:
+		atomic.AddUint64(&s.successes, 1)
+	case retried:
+		atomic.AddUint64(&s.retries, 1)
+	default:
+		atomic.AddUint64(&s.failures, 1)
+	}
+}
+
+// Counts returns the counts so far.
+func (s *RetryStats) Counts() RetryCounts {
+	return RetryCounts{
+		Attempts:  atomic.LoadUint64(&s.attempts),
+		Retries:   atomic.LoadUint64(&s.retries),
+		Successes: atomic.LoadUint64(&s.successes),
+		Failures:  atomic.LoadUint64(&s.failures),
+	}
+}
@@ -0,0 +1,202 @@
+// Package eventstore persists aggregates as append-only streams of events.
+//
+// A stream holds the events of a single aggregate, identified by the
+// aggregate type and ID of their envelopes. The aggregate version of an event
+// is its position in the stream, starting from one.
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/pkg/db"
+	"github.com/janartodesk/domain-design/pkg/outbox"
+	"github.com/uptrace/bun"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrVersionConflict is returned when appending to a stream that has moved
+// past the version the events were raised at.
+var ErrVersionConflict = errors.New("precondition failed: stream version conflict")
+
+// Event is a database model for an event of a stream.
+type Event struct {
+	StreamType string    `bun:"stream_type,pk"`
+	StreamID   uuid.UUID `bun:"stream_id,pk"`
+	Version    uint32    `bun:"version,pk"`
+	EventID    uuid.UUID `bun:"event_id"`
+	Type       string    `bun:"type"`
+	Payload    []byte    `bun:"payload"`
+	RecordedAt time.Time `bun:"recorded_at"`
+
+	bun.BaseModel `bun:"events"`
+}
+
+// Snapshot is a database model for the latest snapshot of a stream.
+type Snapshot struct {
+	StreamType string    `bun:"stream_type,pk"`
+	StreamID   uuid.UUID `bun:"stream_id,pk"`
+	Version    uint32    `bun:"version"`
+	State      []byte    `bun:"state"`
+	CreatedAt  time.Time `bun:"created_at"`
+
+	bun.BaseModel `bun:"snapshots"`
+}
+
+// Append appends an enveloped event to the stream of its aggregate.
+//
+// The aggregate version of the event must directly follow the current version
+// of the stream. Concurrent appends at the same version are rejected by the
+// primary key of the events table, which is returned as ErrVersionConflict
+// too.
+func Append(ctx context.Context, db bun.IDB, env *outbox.Envelope) error {
+	meta := env.Metadata()
+
+	current, err := Version(ctx, db, meta.AggregateType, meta.AggregateID)
+	if err != nil {
+		return err
+	}
+
+	if meta.AggregateVersion != current+1 {
+		return ErrVersionConflict
+	}
+
+	payload, err := proto.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.NewInsert().Model(&Event{
+		StreamType: meta.AggregateType,
+		StreamID:   meta.AggregateID,
+		Version:    meta.AggregateVersion,
+		EventID:    uuid.FromBytesOrNil(env.GetEventID()),
+		Type:       string(env.GetPayload().MessageName()),
+		Payload:    payload,
+		RecordedAt: time.Now(),
+	}).Exec(ctx); err != nil {
+		if isVersionConflict(err) {
+			return ErrVersionConflict
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// isVersionConflict reports whether an append failed for an event at the same
+// version having been appended concurrently.
+func isVersionConflict(err error) bool {
+	return db.IsUniqueViolation(err)
+}
+
+// Version returns the current version of a stream, which is zero for streams
+// without events.
+func Version(ctx context.Context, db bun.IDB, streamType string, streamID uuid.UUID) (uint32, error) {
+	var version uint32
+
+	if err := db.NewSelect().Model((*Event)(nil)).ColumnExpr("COALESCE(MAX(version), 0)").Where(
+		"stream_type = ? AND stream_id = ?",
+		streamType,
+		streamID,
+	).Scan(ctx, &version); err != nil {
+		return 0, err
+	}
+
+	if version > 0 {
+		return version, nil
+	}
+
+	// A truncated stream continues from its snapshot.
+	snapshot, err := LoadSnapshot(ctx, db, streamType, streamID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return snapshot.Version, nil
+}
+
+// Load returns the events of a stream after the given version, in order.
+func Load(ctx context.Context, db bun.IDB, streamType string, streamID uuid.UUID, after uint32) ([]*outbox.Envelope, error) {
+	model := []Event{}
+
+	if err := db.NewSelect().Model(&model).Where(
+		"stream_type = ? AND stream_id = ? AND version > ?",
+		streamType,
+		streamID,
+		after,
+	).Order("version ASC").Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	res := []*outbox.Envelope{}
+
+	for _, event := range model {
+		env := &outbox.Envelope{}
+
+		if err := proto.Unmarshal(event.Payload, env); err != nil {
+			return nil, err
+		}
+
+		res = append(res, env)
+	}
+
+	return res, nil
+}
+
+// SaveSnapshot replaces the snapshot of a stream with the state of its
+// aggregate at the given version.
+func SaveSnapshot(ctx context.Context, db bun.IDB, streamType string, streamID uuid.UUID, version uint32, state []byte) error {
+	if _, err := db.NewInsert().Model(&Snapshot{
+		StreamType: streamType,
+		StreamID:   streamID,
+		Version:    version,
+		State:      state,
+		CreatedAt:  time.Now(),
+	}).On("CONFLICT (stream_type, stream_id) DO UPDATE").
+		Set("version = EXCLUDED.version").
+		Set("state = EXCLUDED.state").
+		Set("created_at = EXCLUDED.created_at").
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// LoadSnapshot returns the snapshot of a stream. It returns sql.ErrNoRows for
+// streams that have not been snapshotted.
+func LoadSnapshot(ctx context.Context, db bun.IDB, streamType string, streamID uuid.UUID) (*Snapshot, error) {
+	model := Snapshot{
+		StreamType: streamType,
+		StreamID:   streamID,
+	}
+
+	if err := db.NewSelect().Model(&model).WherePK().Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &model, nil
+}
+
+// Truncate deletes the events of a stream up to and including the given
+// version. The stream must have been snapshotted at that version, so that the
+// aggregate can still be loaded.
+func Truncate(ctx context.Context, db bun.IDB, streamType string, streamID uuid.UUID, version uint32) error {
+	if _, err := db.NewDelete().Model((*Event)(nil)).Where(
+		"stream_type = ? AND stream_id = ? AND version <= ?",
+		streamType,
+		streamID,
+		version,
+	).Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
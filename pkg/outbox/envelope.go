@@ -0,0 +1,112 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/janartodesk/domain-design/pkg/request"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Metadata describes the origin of an event.
+type Metadata struct {
+	// AggregateType and AggregateID identify the aggregate that raised the
+	// event.
+	AggregateType string
+	AggregateID   uuid.UUID
+	// AggregateVersion is the version of the aggregate the event produced.
+	// It orders the events of an aggregate. It is zero for aggregates that
+	// are not versioned.
+	AggregateVersion uint32
+	OrganizationPK   uuid.UUID
+	// CorrelationID identifies the request that led to the event.
+	CorrelationID string
+	// CausationID identifies the command or event that caused the event.
+	CausationID string
+	// Actor identifies who or what issued the command.
+	Actor string
+}
+
+// Wrap wraps an event payload in an envelope with a new event ID.
+//
+// The correlation ID, causation ID and actor default to the trace ID,
+// causation ID and actor of the request in the context.
+func Wrap(ctx context.Context, payload proto.Message, meta Metadata) (*Envelope, error) {
+	if meta.CorrelationID == "" {
+		meta.CorrelationID = request.TraceID(ctx)
+	}
+
+	if meta.CausationID == "" {
+		meta.CausationID = request.CausationID(ctx)
+	}
+
+	if meta.Actor == "" {
+		meta.Actor = request.Actor(ctx)
+	}
+
+	value, err := anypb.New(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Envelope{
+		EventID:          uuid.Must(uuid.NewV4()).Bytes(),
+		OccurredAt:       timestamppb.New(time.Now()),
+		AggregateType:    meta.AggregateType,
+		AggregateID:      meta.AggregateID.Bytes(),
+		AggregateVersion: meta.AggregateVersion,
+		OrganizationPK:   meta.OrganizationPK.Bytes(),
+		CorrelationID:    meta.CorrelationID,
+		CausationID:      meta.CausationID,
+		Actor:            meta.Actor,
+		Payload:          value,
+	}, nil
+}
+
+// Unwrap returns the event payload of an envelope as its registered proto
+// type.
+func Unwrap(env *Envelope) (proto.Message, error) {
+	return env.GetPayload().UnmarshalNew()
+}
+
+// RedactFunc erases data from an event in place and reports whether the event
+// has changed.
+type RedactFunc func(event proto.Message) bool
+
+// RedactEnvelope redacts the event payload of an envelope, keeping its
+// metadata, and reports whether the event has changed.
+func RedactEnvelope(env *Envelope, redact RedactFunc) (bool, error) {
+	event, err := Unwrap(env)
+	if err != nil {
+		return false, err
+	}
+
+	if !redact(event) {
+		return false, nil
+	}
+
+	value, err := anypb.New(event)
+	if err != nil {
+		return false, err
+	}
+
+	env.Payload = value
+
+	return true, nil
+}
+
+// Metadata returns the metadata of an envelope.
+func (x *Envelope) Metadata() Metadata {
+	return Metadata{
+		AggregateType:    x.GetAggregateType(),
+		AggregateID:      uuid.FromBytesOrNil(x.GetAggregateID()),
+		AggregateVersion: x.GetAggregateVersion(),
+		OrganizationPK:   uuid.FromBytesOrNil(x.GetOrganizationPK()),
+		CorrelationID:    x.GetCorrelationID(),
+		CausationID:      x.GetCausationID(),
+		Actor:            x.GetActor(),
+	}
+}
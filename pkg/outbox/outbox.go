@@ -0,0 +1,120 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/uptrace/bun"
+	"google.golang.org/protobuf/proto"
+)
+
+// Message is a database model for an outbox message.
+//
+// The payload holds the event wrapped in its envelope, while the type is the
+// full name of the wrapped event. The position orders messages by when they
+// were committed and is assigned by the projection runner.
+type Message struct {
+	Seq           int64     `bun:"seq,pk,autoincrement"`
+	Position      int64     `bun:"position,nullzero"`
+	AggregateType string    `bun:"aggregate_type"`
+	AggregateID   uuid.UUID `bun:"aggregate_id"`
+	EventID       uuid.UUID `bun:"event_id"`
+	Type          string    `bun:"type"`
+	Payload       []byte    `bun:"payload"`
+	CreatedAt     time.Time `bun:"created_at"`
+	Attempts      uint32    `bun:"attempts"`
+	LastError     string    `bun:"last_error"`
+	NextAttemptAt time.Time `bun:"next_attempt_at"`
+	PublishedAt   time.Time `bun:"published_at,nullzero"`
+
+	bun.BaseModel `bun:"outbox"`
+}
+
+// Enqueue wraps an event in an envelope and stores it in the outbox.
+//
+// The message is written through db, so when db is a transaction the message
+// is committed or rolled back together with the rest of the transaction.
+func Enqueue(ctx context.Context, db bun.IDB, msg proto.Message, meta Metadata) error {
+	env, err := Wrap(ctx, msg, meta)
+	if err != nil {
+		return err
+	}
+
+	return EnqueueEnvelope(ctx, db, env)
+}
+
+// EnqueueEnvelope stores an event that has been wrapped in an envelope
+// already in the outbox.
+func EnqueueEnvelope(ctx context.Context, db bun.IDB, env *Envelope) error {
+	payload, err := proto.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	if _, err := db.NewInsert().Model(&Message{
+		EventID:       uuid.FromBytesOrNil(env.EventID),
+		AggregateType: env.GetAggregateType(),
+		AggregateID:   uuid.FromBytesOrNil(env.GetAggregateID()),
+		Type:          string(env.GetPayload().MessageName()),
+		Payload:       payload,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}).Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Decode decodes the envelope of the message.
+func (m *Message) Decode() (*Envelope, error) {
+	env := &Envelope{}
+
+	if err := proto.Unmarshal(m.Payload, env); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+// Redact rewrites the stored events an aggregate raised with redact, so that
+// data that must not be kept is erased from the outbox.
+func Redact(ctx context.Context, db bun.IDB, aggregateType string, aggregateID uuid.UUID, redact RedactFunc) error {
+	messages := []Message{}
+
+	if err := db.NewSelect().Model(&messages).Where(
+		"aggregate_type = ? AND aggregate_id = ?",
+		aggregateType,
+		aggregateID,
+	).Scan(ctx); err != nil {
+		return err
+	}
+
+	for i := range messages {
+		msg := &messages[i]
+
+		env, err := msg.Decode()
+		if err != nil {
+			return err
+		}
+
+		if ok, err := RedactEnvelope(env, redact); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+
+		if msg.Payload, err = proto.Marshal(env); err != nil {
+			return err
+		}
+
+		if _, err := db.NewUpdate().Model(msg).Column("payload").WherePK().Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/janartodesk/domain-design/pkg/db"
+	"github.com/uptrace/bun"
+)
+
+const (
+	defaultBatchSize  = 100
+	defaultMaxBackoff = time.Hour
+)
+
+// Publisher publishes enveloped events relayed from the outbox. The context is
+// that of the relay.
+type Publisher interface {
+	Publish(context.Context, *Envelope) error
+}
+
+// Relay publishes outbox messages with at-least-once delivery.
+//
+// Messages are published in the order they were enqueued. A message that fails
+// to publish is retried with exponential backoff and blocks every message after
+// it until it has been published.
+type Relay struct {
+	db        *bun.DB
+	publisher Publisher
+
+	BatchSize  int
+	MaxBackoff time.Duration
+
+	// OnError is called by Run with the errors relaying a batch fails with.
+	OnError func(error)
+}
+
+// NewRelay creates an outbox relay.
+func NewRelay(db *bun.DB, publisher Publisher) *Relay {
+	return &Relay{
+		db:         db,
+		publisher:  publisher,
+		BatchSize:  defaultBatchSize,
+		MaxBackoff: defaultMaxBackoff,
+	}
+}
+
+// Run relays outbox messages until the context is cancelled. Errors are
+// reported to OnError, and the message that failed is retried once its backoff
+// has passed.
+func (r *Relay) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := r.Process(ctx)
+			if err != nil {
+				if ctx.Err() == nil && r.OnError != nil {
+					r.OnError(err)
+				}
+
+				break
+			}
+
+			if n < r.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Process publishes the next batch of pending outbox messages and returns the
+// number of messages published.
+//
+// The returned error is the publishing error of the message the batch stopped
+// at. Its retry state has been recorded when it is returned.
+func (r *Relay) Process(ctx context.Context) (int, error) {
+	var (
+		published  int
+		publishErr error
+	)
+
+	err := db.WithTransaction(ctx, r.db, func(ctx context.Context, tx bun.Tx) error {
+		messages := []Message{}
+
+		if err := tx.NewSelect().Model(&messages).
+			Where("published_at IS NULL").
+			Order("seq ASC").
+			Limit(r.BatchSize).
+			For("UPDATE").
+			Scan(ctx); err != nil {
+			return err
+		}
+
+		for i := range messages {
+			msg := &messages[i]
+			now := time.Now()
+
+			if msg.NextAttemptAt.After(now) {
+				return nil
+			}
+
+			msg.Attempts++
+
+			if publishErr = r.publish(ctx, msg); publishErr != nil {
+				msg.LastError = publishErr.Error()
+				msg.NextAttemptAt = now.Add(r.backoff(msg.Attempts))
+			} else {
+				msg.LastError = ""
+				msg.PublishedAt = now
+			}
+
+			if _, err := tx.NewUpdate().Model(msg).
+				Column("attempts", "last_error", "next_attempt_at", "published_at").
+				WherePK().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			if publishErr != nil {
+				return nil
+			}
+
+			published++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return published, publishErr
+}
+
+func (r *Relay) publish(ctx context.Context, msg *Message) error {
+	env, err := msg.Decode()
+	if err != nil {
+		return err
+	}
+
+	return r.publisher.Publish(ctx, env)
+}
+
+func (r *Relay) backoff(attempts uint32) time.Duration {
+	d := time.Second
+
+	for i := uint32(1); i < attempts && d < r.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	if d > r.MaxBackoff {
+		d = r.MaxBackoff
+	}
+
+	return d
+}
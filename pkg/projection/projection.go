@@ -0,0 +1,270 @@
+// Package projection maintains read models by applying the events of the
+// outbox to projections.
+//
+// The outbox doubles as the event log: messages are kept once published. As
+// sequence numbers are taken before transactions commit, a message may become
+// visible after one with a higher sequence number. The runner therefore gives
+// committed messages a position, one transaction at a time, so that positions
+// are never committed behind one a projection has read. Each projection tracks
+// the position of the last message it applied as its checkpoint.
+package projection
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/janartodesk/domain-design/pkg/db"
+	"github.com/janartodesk/domain-design/pkg/outbox"
+	"github.com/uptrace/bun"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultBatchSize = 500
+
+// Projection applies events to a read model.
+type Projection interface {
+	// Name identifies the projection's checkpoint. It must be stable across
+	// deployments.
+	Name() string
+	// Apply applies an event to the read model. Events the projection is not
+	// interested in are ignored.
+	Apply(ctx context.Context, tx bun.IDB, env *outbox.Envelope, event proto.Message) error
+	// Reset clears the read model, so that it can be rebuilt from the first
+	// event.
+	Reset(ctx context.Context, tx bun.IDB) error
+}
+
+// Checkpoint is a database model for the position of a projection in the
+// outbox.
+type Checkpoint struct {
+	Name      string    `bun:"name,pk"`
+	Position  int64     `bun:"position"`
+	UpdatedAt time.Time `bun:"updated_at"`
+
+	bun.BaseModel `bun:"projection_checkpoints"`
+}
+
+// Runner keeps projections up to date with the outbox.
+//
+// Messages are applied in the order of their positions. Messages committed
+// together are positioned in sequence order.
+type Runner struct {
+	db          *bun.DB
+	projections []Projection
+
+	BatchSize int
+}
+
+// NewRunner creates a projection runner.
+func NewRunner(db *bun.DB, projections ...Projection) *Runner {
+	return &Runner{
+		db:          db,
+		projections: projections,
+		BatchSize:   defaultBatchSize,
+	}
+}
+
+// Run applies outbox messages to the projections until the context is
+// cancelled.
+func (r *Runner) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := r.Process(ctx)
+			if err != nil || n == 0 {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Process positions the next batch of committed messages, then applies the
+// next batch of messages to each projection and returns the number of messages
+// applied.
+//
+// The returned error is the first error a projection failed with. The batch
+// of a failing projection is rolled back along with its checkpoint, and other
+// projections are processed regardless.
+func (r *Runner) Process(ctx context.Context) (int, error) {
+	var (
+		applied  int
+		firstErr error
+	)
+
+	if err := r.position(ctx); err != nil {
+		return 0, err
+	}
+
+	for _, p := range r.projections {
+		if err := ctx.Err(); err != nil {
+			return applied, err
+		}
+
+		n, err := r.process(ctx, p)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			continue
+		}
+
+		applied += n
+	}
+
+	return applied, firstErr
+}
+
+// position gives the next batch of committed messages without a position the
+// positions following the highest one, in sequence order.
+//
+// Runners position messages one at a time by holding an advisory lock until
+// they commit. A message that is not visible yet is positioned by a later
+// transaction, after every position a projection may have read.
+func (r *Runner) position(ctx context.Context) error {
+	return db.WithTransaction(ctx, r.db, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('outbox_position'))"); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			UPDATE outbox SET position = positioned.position
+			FROM (
+				SELECT seq, (SELECT COALESCE(MAX(position), 0) FROM outbox) + row_number() OVER (ORDER BY seq) AS position
+				FROM outbox
+				WHERE position IS NULL
+				ORDER BY seq
+				LIMIT ?
+			) AS positioned
+			WHERE outbox.seq = positioned.seq`, r.BatchSize)
+
+		return err
+	})
+}
+
+func (r *Runner) process(ctx context.Context, p Projection) (int, error) {
+	var n int
+
+	err := db.WithTransaction(ctx, r.db, func(ctx context.Context, tx bun.Tx) error {
+		checkpoint, err := lockCheckpoint(ctx, tx, p.Name())
+		if err != nil {
+			return err
+		}
+
+		messages := []outbox.Message{}
+
+		if err := tx.NewSelect().Model(&messages).
+			Where("position > ?", checkpoint.Position).
+			Order("position ASC").
+			Limit(r.BatchSize).
+			Scan(ctx); err != nil {
+			return err
+		}
+
+		if len(messages) == 0 {
+			return nil
+		}
+
+		for i := range messages {
+			env, err := messages[i].Decode()
+			if err != nil {
+				return err
+			}
+
+			event, err := outbox.Unwrap(env)
+			if err != nil {
+				return err
+			}
+
+			if err := p.Apply(ctx, tx, env, event); err != nil {
+				return err
+			}
+		}
+
+		checkpoint.Position = messages[len(messages)-1].Position
+		checkpoint.UpdatedAt = time.Now()
+
+		if _, err := tx.NewUpdate().Model(checkpoint).
+			Column("position", "updated_at").
+			WherePK().
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		n = len(messages)
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// Rebuild clears the read model of the named projection and rewinds its
+// checkpoint, so that the runner rebuilds it from the first event.
+func (r *Runner) Rebuild(ctx context.Context, name string) error {
+	for _, p := range r.projections {
+		if p.Name() != name {
+			continue
+		}
+
+		return db.WithTransaction(ctx, r.db, func(ctx context.Context, tx bun.Tx) error {
+			checkpoint, err := lockCheckpoint(ctx, tx, p.Name())
+			if err != nil {
+				return err
+			}
+
+			if err := p.Reset(ctx, tx); err != nil {
+				return err
+			}
+
+			checkpoint.Position = 0
+			checkpoint.UpdatedAt = time.Now()
+
+			if _, err := tx.NewUpdate().Model(checkpoint).
+				Column("position", "updated_at").
+				WherePK().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	return sql.ErrNoRows
+}
+
+// lockCheckpoint returns the checkpoint of a projection, locked for the rest
+// of the transaction so that a projection is only ever run by one runner at a
+// time. A projection without a checkpoint starts from the first message.
+func lockCheckpoint(ctx context.Context, tx bun.Tx, name string) (*Checkpoint, error) {
+	if _, err := tx.NewInsert().Model(&Checkpoint{
+		Name:      name,
+		UpdatedAt: time.Now(),
+	}).On("CONFLICT DO NOTHING").Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	checkpoint := &Checkpoint{
+		Name: name,
+	}
+
+	if err := tx.NewSelect().Model(checkpoint).WherePK().For("UPDATE").Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
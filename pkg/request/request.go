@@ -0,0 +1,53 @@
+// Package request carries request-scoped values in a context, so that they
+// reach the events issued on behalf of a request.
+package request
+
+import (
+	"context"
+)
+
+type key int
+
+const (
+	actorKey key = iota
+	traceIDKey
+	causationIDKey
+)
+
+// WithActor returns a context carrying who or what issued a request.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// Actor returns who or what issued a request.
+func Actor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey).(string)
+
+	return actor
+}
+
+// WithTraceID returns a context carrying the ID of the trace a request belongs
+// to. Events raised by the request are correlated by it.
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDKey, id)
+}
+
+// TraceID returns the ID of the trace a request belongs to.
+func TraceID(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey).(string)
+
+	return id
+}
+
+// WithCausationID returns a context carrying the ID of the command or event
+// that caused a request.
+func WithCausationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, causationIDKey, id)
+}
+
+// CausationID returns the ID of the command or event that caused a request.
+func CausationID(ctx context.Context) string {
+	id, _ := ctx.Value(causationIDKey).(string)
+
+	return id
+}